@@ -28,6 +28,7 @@ import (
 	"github.com/redhat-developer/service-binding-operator/pkg/apis"
 	"github.com/redhat-developer/service-binding-operator/pkg/controller"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
+	"github.com/redhat-developer/service-binding-operator/pkg/webhook"
 )
 
 // Change below variables to serve metrics on different host or port.
@@ -35,6 +36,7 @@ var (
 	metricsHost               = "0.0.0.0"
 	metricsPort         int32 = 8383
 	operatorMetricsPort int32 = 8686
+	webhookPort               = 9443
 	mainLog                   = log.NewLog("main")
 )
 
@@ -59,6 +61,12 @@ func isLeaderElectionEnabled() bool {
 	return os.Getenv("SERVICE_BINDING_OPERATOR_DISABLE_ELECTION") == ""
 }
 
+// isWebhookEnabled based on environment variable SERVICE_BINDING_OPERATOR_ENABLE_WEBHOOK, webhooks
+// are disabled by default since they require a serving certificate and a webhook configuration.
+func isWebhookEnabled() bool {
+	return os.Getenv("SERVICE_BINDING_OPERATOR_ENABLE_WEBHOOK") != ""
+}
+
 func main() {
 	pflag.CommandLine.AddFlagSet(zap.FlagSet())
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
@@ -98,6 +106,8 @@ func main() {
 	mgr, err := manager.New(cfg, manager.Options{
 		Namespace:          namespace,
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
+		Port:               webhookPort,
+		CertDir:            os.Getenv("SERVICE_BINDING_OPERATOR_WEBHOOK_CERT_DIR"),
 	})
 	if err != nil {
 		mainLog.Error(err, "Error on creating a new manager instance")
@@ -123,6 +133,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	if isWebhookEnabled() {
+		// Setup all Webhooks
+		if err := webhook.AddToManager(mgr); err != nil {
+			mainLog.Error(err, "Failed to setup the webhooks")
+			os.Exit(1)
+		}
+	} else {
+		mainLog.Warning("Webhooks are disabled")
+	}
+
 	if err = serveCRMetrics(cfg); err != nil {
 		mainLog.Info("Could not generate and serve custom resource metrics", "error", err.Error())
 	}
@@ -0,0 +1,10 @@
+package apis
+
+import (
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3"
+)
+
+func init() {
+	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
+	AddToSchemes = append(AddToSchemes, v1alpha3.SchemeBuilder.AddToScheme)
+}
@@ -0,0 +1,61 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
+
+// BindableServiceSpec defines the binding descriptors of a backing service kind
+// +k8s:openapi-gen=true
+type BindableServiceSpec struct {
+	// GroupVersionKind is the backing service kind described, all versions are described when the
+	// version is not informed.
+	metav1.GroupVersionKind `json:",inline"`
+
+	// SpecDescriptors describe binding data read from the "spec" section of backing services,
+	// using the same vocabulary as OLM spec descriptors.
+	// +optional
+	SpecDescriptors []BindableServiceDescriptor `json:"specDescriptors,omitempty"`
+
+	// StatusDescriptors describe binding data read from the "status" section of backing services,
+	// using the same vocabulary as OLM status descriptors.
+	// +optional
+	StatusDescriptors []BindableServiceDescriptor `json:"statusDescriptors,omitempty"`
+}
+
+// BindableServiceDescriptor describes binding data found in a field path, for instance the
+// "binding:env:object:secret:user" x-descriptor of a path referring to a secret.
+// +k8s:openapi-gen=true
+type BindableServiceDescriptor struct {
+	// Path is the field path, relative to the section the descriptor belongs to.
+	Path string `json:"path"`
+	// XDescriptors are the binding descriptors of the field path.
+	XDescriptors []string `json:"x-descriptors"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BindableService declares binding descriptors for a backing service kind, for CRDs that can't be
+// annotated nor are described by a ClusterServiceVersion.
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:path=bindableservices,scope=Cluster
+type BindableService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BindableServiceSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BindableServiceList contains a list of BindableService
+type BindableServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BindableService `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BindableService{}, &BindableServiceList{})
+}
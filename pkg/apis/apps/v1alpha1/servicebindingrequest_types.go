@@ -46,12 +46,55 @@ type ServiceBindingRequestSpec struct {
 	// +optional
 	ApplicationSelector ApplicationSelector `json:"applicationSelector"`
 
+	// ApplicationSelectors is used to identify multiple applications, possibly of different
+	// kinds, connecting to the backing services, along with 'ApplicationSelector' when informed.
+	// +optional
+	ApplicationSelectors *[]ApplicationSelector `json:"applicationSelectors,omitempty"`
+
 	// DetectBindingResources is flag used to bind all non-bindable variables from
 	// different subresources owned by backing operator CR.
 	// +optional
 	DetectBindingResources bool `json:"detectBindingResources"`
+
+	// BindAsFiles projects the binding data as files in application containers, following the
+	// servicebinding.io directory layout "$SERVICE_BINDING_ROOT/<binding name>/<key>", instead of
+	// exposing it as environment variables. The root directory is the container's
+	// SERVICE_BINDING_ROOT environment variable when declared, otherwise "mountPathPrefix", or
+	// "/bindings" by default, being declared as SERVICE_BINDING_ROOT.
+	// +optional
+	BindAsFiles bool `json:"bindAsFiles,omitempty"`
+
+	// Type is the "type" entry of the binding projected as files, the lower-cased kind of the
+	// first backing service by default.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Provider is the "provider" entry of the binding projected as files, the API group of the
+	// first backing service by default.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// RestartStrategy defines how application workloads are restarted when the binding data
+	// changes. "HashAnnotation" (default) stores a hash of the data as a pod template annotation,
+	// "EnvVar" stores it as an environment variable and "None" doesn't restart workloads.
+	// +optional
+	RestartStrategy RestartStrategy `json:"restartStrategy,omitempty"`
 }
 
+// RestartStrategy is the strategy used to restart application workloads when the binding data
+// changes.
+// +kubebuilder:validation:Enum=HashAnnotation;EnvVar;None
+type RestartStrategy string
+
+const (
+	// RestartStrategyHashAnnotation stores the binding data hash as a pod template annotation.
+	RestartStrategyHashAnnotation RestartStrategy = "HashAnnotation"
+	// RestartStrategyEnvVar stores the binding data hash as an environment variable.
+	RestartStrategyEnvVar RestartStrategy = "EnvVar"
+	// RestartStrategyNone doesn't restart workloads when the binding data changes.
+	RestartStrategyNone RestartStrategy = "None"
+)
+
 // ServiceBindingRequestStatus defines the observed state of ServiceBindingRequest
 // +k8s:openapi-gen=true
 type ServiceBindingRequestStatus struct {
@@ -63,6 +106,36 @@ type ServiceBindingRequestStatus struct {
 	Secret string `json:"secret,omitempty"`
 	// ApplicationObjects contains all the application objects filtered by label
 	ApplicationObjects []BoundApplication `json:"applications,omitempty"`
+	// ApplicationGroups contains the bound application objects grouped by the application
+	// selector they were selected by.
+	ApplicationGroups []BoundApplicationGroup `json:"applicationGroups,omitempty"`
+	// DriftCount is the number of times bound applications were found missing binding items, which
+	// were then re-applied.
+	DriftCount int64 `json:"driftCount,omitempty"`
+	// DeniedReads are the reads of backing service resources denied to the user requesting the
+	// service binding request, which were not bound.
+	DeniedReads []DeniedRead `json:"deniedReads,omitempty"`
+}
+
+// DeniedRead is a read of a backing service resource denied to the user requesting the service
+// binding request.
+// +k8s:openapi-gen=true
+type DeniedRead struct {
+	// Verb is the denied verb, "get" or "list".
+	Verb string `json:"verb"`
+	// Group is the API group of the resource, empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// Resource is the resource type, such as "secrets".
+	Resource string `json:"resource"`
+	// Namespace is the namespace of the resource.
+	Namespace string `json:"namespace"`
+	// Name is the name of the resource, empty when listing resources was denied.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Reason is the reason given by the authorizer, when any.
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 // BackingServiceSelector defines the selector based on resource name, version, and resource kind
@@ -72,6 +145,14 @@ type BackingServiceSelector struct {
 	ResourceRef             string `json:"resourceRef"`
 	// +optional
 	Namespace *string `json:"namespace,omitempty"`
+	// ID identifies the backing service among the selected ones, its binding keys being namespaced
+	// by it instead of by the backing service kind.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// EnvVarPrefix replaces both the service binding request prefix and the id or kind namespacing
+	// the binding keys of the backing service, an empty value leaving them unprefixed.
+	// +optional
+	EnvVarPrefix *string `json:"envVarPrefix,omitempty"`
 }
 
 // BoundApplication defines the application workloads to which the binding secret has
@@ -79,6 +160,20 @@ type BackingServiceSelector struct {
 type BoundApplication struct {
 	metav1.GroupVersionKind `json:",inline"`
 	v1.LocalObjectReference `json:",inline"`
+	// Namespace is the namespace of the application, when not the service binding request
+	// namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// BoundApplicationGroup defines the application workloads bound through an application selector.
+// +k8s:openapi-gen=true
+type BoundApplicationGroup struct {
+	// Selector is the application selector the workloads were selected by.
+	Selector ApplicationSelector `json:"selector"`
+	// Applications are the workloads bound through the selector.
+	// +optional
+	Applications []BoundApplication `json:"applications,omitempty"`
 }
 
 // ApplicationSelector defines the selector based on labels and GVR
@@ -88,6 +183,30 @@ type ApplicationSelector struct {
 	LabelSelector               *metav1.LabelSelector `json:"labelSelector,omitempty"`
 	metav1.GroupVersionResource `json:",inline"`
 	ResourceRef                 string `json:"resourceRef,omitempty"`
+	// Namespace is the namespace of the application, the service binding request namespace by
+	// default. Other namespaces must allow bindings from the service binding request namespace
+	// with the "service-binding-operator.apps.openshift.io/allow-bindings-from" annotation.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// NamespaceSelector selects the namespaces of the application by labels, instead of
+	// Namespace. Selected namespaces not allowing bindings from the service binding request
+	// namespace are skipped.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// BindingPath declares where containers and volumes are found in the application workloads,
+	// for kinds not handled by the built-in workload adapters.
+	// +optional
+	BindingPath *BindingPath `json:"bindingPath,omitempty"`
+}
+
+// BindingPath declares the dotted paths of containers and volumes in application workloads.
+// +k8s:openapi-gen=true
+type BindingPath struct {
+	// ContainersPath is the path of the containers, such as "spec.containers".
+	ContainersPath string `json:"containersPath"`
+	// VolumesPath is the path of the volumes, the "volumes" sibling of the containers by default.
+	// +optional
+	VolumesPath string `json:"volumesPath,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
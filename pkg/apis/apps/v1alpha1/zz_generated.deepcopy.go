@@ -20,6 +20,16 @@ func (in *ApplicationSelector) DeepCopyInto(out *ApplicationSelector) {
 		(*in).DeepCopyInto(*out)
 	}
 	out.GroupVersionResource = in.GroupVersionResource
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BindingPath != nil {
+		in, out := &in.BindingPath, &out.BindingPath
+		*out = new(BindingPath)
+		**out = **in
+	}
 	return
 }
 
@@ -42,6 +52,11 @@ func (in *BackingServiceSelector) DeepCopyInto(out *BackingServiceSelector) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.EnvVarPrefix != nil {
+		in, out := &in.EnvVarPrefix, &out.EnvVarPrefix
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -55,6 +70,134 @@ func (in *BackingServiceSelector) DeepCopy() *BackingServiceSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BindableService) DeepCopyInto(out *BindableService) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindableService.
+func (in *BindableService) DeepCopy() *BindableService {
+	if in == nil {
+		return nil
+	}
+	out := new(BindableService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BindableService) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BindableServiceDescriptor) DeepCopyInto(out *BindableServiceDescriptor) {
+	*out = *in
+	if in.XDescriptors != nil {
+		in, out := &in.XDescriptors, &out.XDescriptors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindableServiceDescriptor.
+func (in *BindableServiceDescriptor) DeepCopy() *BindableServiceDescriptor {
+	if in == nil {
+		return nil
+	}
+	out := new(BindableServiceDescriptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BindableServiceList) DeepCopyInto(out *BindableServiceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BindableService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindableServiceList.
+func (in *BindableServiceList) DeepCopy() *BindableServiceList {
+	if in == nil {
+		return nil
+	}
+	out := new(BindableServiceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BindableServiceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BindableServiceSpec) DeepCopyInto(out *BindableServiceSpec) {
+	*out = *in
+	out.GroupVersionKind = in.GroupVersionKind
+	if in.SpecDescriptors != nil {
+		in, out := &in.SpecDescriptors, &out.SpecDescriptors
+		*out = make([]BindableServiceDescriptor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StatusDescriptors != nil {
+		in, out := &in.StatusDescriptors, &out.StatusDescriptors
+		*out = make([]BindableServiceDescriptor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindableServiceSpec.
+func (in *BindableServiceSpec) DeepCopy() *BindableServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BindableServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BindingPath) DeepCopyInto(out *BindingPath) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindingPath.
+func (in *BindingPath) DeepCopy() *BindingPath {
+	if in == nil {
+		return nil
+	}
+	out := new(BindingPath)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BoundApplication) DeepCopyInto(out *BoundApplication) {
 	*out = *in
@@ -73,6 +216,44 @@ func (in *BoundApplication) DeepCopy() *BoundApplication {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BoundApplicationGroup) DeepCopyInto(out *BoundApplicationGroup) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make([]BoundApplication, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BoundApplicationGroup.
+func (in *BoundApplicationGroup) DeepCopy() *BoundApplicationGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(BoundApplicationGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeniedRead) DeepCopyInto(out *DeniedRead) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeniedRead.
+func (in *DeniedRead) DeepCopy() *DeniedRead {
+	if in == nil {
+		return nil
+	}
+	out := new(DeniedRead)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBindingRequest) DeepCopyInto(out *ServiceBindingRequest) {
 	*out = *in
@@ -161,6 +342,17 @@ func (in *ServiceBindingRequestSpec) DeepCopyInto(out *ServiceBindingRequestSpec
 		}
 	}
 	in.ApplicationSelector.DeepCopyInto(&out.ApplicationSelector)
+	if in.ApplicationSelectors != nil {
+		in, out := &in.ApplicationSelectors, &out.ApplicationSelectors
+		*out = new([]ApplicationSelector)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]ApplicationSelector, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
 	return
 }
 
@@ -189,6 +381,18 @@ func (in *ServiceBindingRequestStatus) DeepCopyInto(out *ServiceBindingRequestSt
 		*out = make([]BoundApplication, len(*in))
 		copy(*out, *in)
 	}
+	if in.ApplicationGroups != nil {
+		in, out := &in.ApplicationGroups, &out.ApplicationGroups
+		*out = make([]BoundApplicationGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeniedReads != nil {
+		in, out := &in.DeniedReads, &out.DeniedReads
+		*out = make([]DeniedRead, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
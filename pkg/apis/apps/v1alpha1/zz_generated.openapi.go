@@ -13,6 +13,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 	return map[string]common.OpenAPIDefinition{
 		"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.ApplicationSelector":         schema_pkg_apis_apps_v1alpha1_ApplicationSelector(ref),
 		"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BackingServiceSelector":      schema_pkg_apis_apps_v1alpha1_BackingServiceSelector(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BindableService":             schema_pkg_apis_apps_v1alpha1_BindableService(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BindableServiceDescriptor":   schema_pkg_apis_apps_v1alpha1_BindableServiceDescriptor(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BindableServiceSpec":         schema_pkg_apis_apps_v1alpha1_BindableServiceSpec(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BindingPath":                 schema_pkg_apis_apps_v1alpha1_BindingPath(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BoundApplicationGroup":       schema_pkg_apis_apps_v1alpha1_BoundApplicationGroup(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.DeniedRead":                  schema_pkg_apis_apps_v1alpha1_DeniedRead(ref),
 		"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.ServiceBindingRequest":       schema_pkg_apis_apps_v1alpha1_ServiceBindingRequest(ref),
 		"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.ServiceBindingRequestSpec":   schema_pkg_apis_apps_v1alpha1_ServiceBindingRequestSpec(ref),
 		"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.ServiceBindingRequestStatus": schema_pkg_apis_apps_v1alpha1_ServiceBindingRequestStatus(ref),
@@ -55,12 +61,31 @@ func schema_pkg_apis_apps_v1alpha1_ApplicationSelector(ref common.ReferenceCallb
 							Format: "",
 						},
 					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace is the namespace of the application, the service binding request namespace by default. Other namespaces must allow bindings from the service binding request namespace with the \"service-binding-operator.apps.openshift.io/allow-bindings-from\" annotation.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespaceSelector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NamespaceSelector selects the namespaces of the application by labels, instead of Namespace. Selected namespaces not allowing bindings from the service binding request namespace are skipped.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+					"bindingPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BindingPath declares where containers and volumes are found in the application workloads, for kinds not handled by the built-in workload adapters.",
+							Ref:         ref("github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BindingPath"),
+						},
+					},
 				},
 				Required: []string{"group", "version", "resource"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+			"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BindingPath", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
 	}
 }
 
@@ -101,6 +126,20 @@ func schema_pkg_apis_apps_v1alpha1_BackingServiceSelector(ref common.ReferenceCa
 							Format: "",
 						},
 					},
+					"id": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ID identifies the backing service among the selected ones, its binding keys being namespaced by it instead of by the backing service kind.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"envVarPrefix": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EnvVarPrefix replaces both the service binding request prefix and the id or kind namespacing the binding keys of the backing service, an empty value leaving them unprefixed.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"group", "version", "kind", "resourceRef"},
 			},
@@ -108,6 +147,259 @@ func schema_pkg_apis_apps_v1alpha1_BackingServiceSelector(ref common.ReferenceCa
 	}
 }
 
+func schema_pkg_apis_apps_v1alpha1_BindableService(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BindableService declares binding descriptors for a backing service kind, for CRDs that can't be annotated nor are described by a ClusterServiceVersion.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BindableServiceSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BindableServiceSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_apps_v1alpha1_BindableServiceDescriptor(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BindableServiceDescriptor describes binding data found in a field path, for instance the \"binding:env:object:secret:user\" x-descriptor of a path referring to a secret.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the field path, relative to the section the descriptor belongs to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"x-descriptors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "XDescriptors are the binding descriptors of the field path.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"path", "x-descriptors"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apps_v1alpha1_BindableServiceSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BindableServiceSpec defines the binding descriptors of a backing service kind",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"specDescriptors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SpecDescriptors describe binding data read from the \"spec\" section of backing services, using the same vocabulary as OLM spec descriptors.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BindableServiceDescriptor"),
+									},
+								},
+							},
+						},
+					},
+					"statusDescriptors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StatusDescriptors describe binding data read from the \"status\" section of backing services, using the same vocabulary as OLM status descriptors.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BindableServiceDescriptor"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"group", "version", "kind"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BindableServiceDescriptor"},
+	}
+}
+
+func schema_pkg_apis_apps_v1alpha1_BindingPath(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BindingPath declares the dotted paths of containers and volumes in application workloads.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"containersPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ContainersPath is the path of the containers, such as \"spec.containers\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"volumesPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "VolumesPath is the path of the volumes, the \"volumes\" sibling of the containers by default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"containersPath"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_apps_v1alpha1_BoundApplicationGroup(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BoundApplicationGroup defines the application workloads bound through an application selector.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector is the application selector the workloads were selected by.",
+							Ref:         ref("github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.ApplicationSelector"),
+						},
+					},
+					"applications": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Applications are the workloads bound through the selector.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BoundApplication"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"selector"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.ApplicationSelector", "github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BoundApplication"},
+	}
+}
+
+func schema_pkg_apis_apps_v1alpha1_DeniedRead(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "DeniedRead is a read of a backing service resource denied to the user requesting the service binding request.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"verb": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Verb is the denied verb, \"get\" or \"list\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"group": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Group is the API group of the resource, empty for the core group.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"resource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Resource is the resource type, such as \"secrets\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"namespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Namespace is the namespace of the resource.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the resource, empty when listing resources was denied.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Reason is the reason given by the authorizer, when any.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"verb", "resource", "namespace"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_apps_v1alpha1_ServiceBindingRequest(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -211,6 +503,19 @@ func schema_pkg_apis_apps_v1alpha1_ServiceBindingRequestSpec(ref common.Referenc
 							Ref:         ref("github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.ApplicationSelector"),
 						},
 					},
+					"applicationSelectors": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ApplicationSelectors is used to identify multiple applications, possibly of different kinds, connecting to the backing services, along with 'ApplicationSelector' when informed.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.ApplicationSelector"),
+									},
+								},
+							},
+						},
+					},
 					"detectBindingResources": {
 						SchemaProps: spec.SchemaProps{
 							Description: "DetectBindingResources is flag used to bind all non-bindable variables from different subresources owned by backing operator CR.",
@@ -218,6 +523,34 @@ func schema_pkg_apis_apps_v1alpha1_ServiceBindingRequestSpec(ref common.Referenc
 							Format:      "",
 						},
 					},
+					"bindAsFiles": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BindAsFiles projects the binding data as files in application containers, following the servicebinding.io directory layout \"$SERVICE_BINDING_ROOT/<binding name>/<key>\", instead of exposing it as environment variables. The root directory is the container's SERVICE_BINDING_ROOT environment variable when declared, otherwise \"mountPathPrefix\", or \"/bindings\" by default, being declared as SERVICE_BINDING_ROOT.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the \"type\" entry of the binding projected as files, the lower-cased kind of the first backing service by default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"provider": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Provider is the \"provider\" entry of the binding projected as files, the API group of the first backing service by default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"restartStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RestartStrategy defines how application workloads are restarted when the binding data changes. \"HashAnnotation\" (default) stores a hash of the data as a pod template annotation, \"EnvVar\" stores it as an environment variable and \"None\" doesn't restart workloads.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -273,10 +606,43 @@ func schema_pkg_apis_apps_v1alpha1_ServiceBindingRequestStatus(ref common.Refere
 							},
 						},
 					},
+					"applicationGroups": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ApplicationGroups contains the bound application objects grouped by the application selector they were selected by.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BoundApplicationGroup"),
+									},
+								},
+							},
+						},
+					},
+					"driftCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DriftCount is the number of times bound applications were found missing binding items, which were then re-applied.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"deniedReads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeniedReads are the reads of backing service resources denied to the user requesting the service binding request, which were not bound.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.DeniedRead"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/openshift/custom-resource-status/conditions/v1.Condition", "github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BoundApplication"},
+			"github.com/openshift/custom-resource-status/conditions/v1.Condition", "github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BoundApplication", "github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.BoundApplicationGroup", "github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1.DeniedRead"},
 	}
 }
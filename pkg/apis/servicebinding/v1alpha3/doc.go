@@ -0,0 +1,4 @@
+// Package v1alpha3 contains API Schema definitions for the servicebinding v1alpha3 API group
+// +k8s:deepcopy-gen=package,register
+// +groupName=servicebinding.io
+package v1alpha3
@@ -0,0 +1,145 @@
+package v1alpha3
+
+import (
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
+
+// ServiceBindingSpec defines the desired state of ServiceBinding
+// +k8s:openapi-gen=true
+type ServiceBindingSpec struct {
+	// Type is the "type" entry of the binding, the lower-cased kind of the service by default.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Provider is the "provider" entry of the binding, the API group of the service by default.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+
+	// Workload is the reference to the application workloads the service is bound to, either by
+	// name or by label selector.
+	Workload ServiceBindingWorkloadReference `json:"workload"`
+
+	// Service is the reference to the backing service.
+	Service ServiceBindingServiceReference `json:"service"`
+
+	// Env projects binding entries as environment variables in the workload containers, besides
+	// projecting all of them as files.
+	// +optional
+	Env []EnvMapping `json:"env,omitempty"`
+
+	// Mappings are additional binding entries, whose values are templates evaluated the same way
+	// ServiceBindingRequest's "customEnvVar" are.
+	// +optional
+	Mappings []ServiceBindingMapping `json:"mappings,omitempty"`
+}
+
+// ServiceBindingWorkloadReference refers to the application workloads, by name or by label
+// selector.
+// +k8s:openapi-gen=true
+type ServiceBindingWorkloadReference struct {
+	// APIVersion is the API version of the workloads.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the workloads.
+	Kind string `json:"kind"`
+	// Name is the name of the workload, taking precedence over the selector.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Selector selects the workloads by labels.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// ServiceBindingServiceReference refers to the backing service, in the same namespace.
+// +k8s:openapi-gen=true
+type ServiceBindingServiceReference struct {
+	// APIVersion is the API version of the service.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the service.
+	Kind string `json:"kind"`
+	// Name is the name of the service.
+	Name string `json:"name"`
+}
+
+// EnvMapping projects a binding entry as an environment variable.
+// +k8s:openapi-gen=true
+type EnvMapping struct {
+	// Name is the name of the environment variable.
+	Name string `json:"name"`
+	// Key is the binding entry projected.
+	Key string `json:"key"`
+}
+
+// ServiceBindingMapping is an additional binding entry, whose value is a template.
+// +k8s:openapi-gen=true
+type ServiceBindingMapping struct {
+	// Name is the name of the binding entry.
+	Name string `json:"name"`
+	// Value is the template rendering the value of the binding entry.
+	Value string `json:"value"`
+}
+
+// ServiceBindingStatus defines the observed state of ServiceBinding
+// +k8s:openapi-gen=true
+type ServiceBindingStatus struct {
+	// ObservedGeneration is the generation of the ServiceBinding the status refers to.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describes the state of the operator's reconciliation functionality.
+	// +optional
+	Conditions []conditionsv1.Condition `json:"conditions,omitempty"`
+	// Binding is the secret holding the binding entries.
+	// +optional
+	Binding *corev1.LocalObjectReference `json:"binding,omitempty"`
+	// Workloads are the workloads the binding has been projected into.
+	// +optional
+	Workloads []BoundWorkload `json:"workloads,omitempty"`
+	// DriftCount is the number of times bound workloads were found missing binding items, which
+	// were then re-applied.
+	// +optional
+	DriftCount int64 `json:"driftCount,omitempty"`
+}
+
+// BoundWorkload refers to a workload the binding has been projected into.
+// +k8s:openapi-gen=true
+type BoundWorkload struct {
+	// APIVersion is the API version of the workload.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the workload.
+	Kind string `json:"kind"`
+	// Name is the name of the workload.
+	Name string `json:"name"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceBinding expresses intent to bind a backing service with application workloads, following
+// the servicebinding.io specification. It's handled the same way a ServiceBindingRequest is, having
+// its binding projected as files.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=servicebindings
+type ServiceBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServiceBindingSpec   `json:"spec,omitempty"`
+	Status ServiceBindingStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceBindingList contains a list of ServiceBinding
+type ServiceBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ServiceBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServiceBinding{}, &ServiceBindingList{})
+}
@@ -0,0 +1,365 @@
+// +build !ignore_autogenerated
+
+// This file was autogenerated by openapi-gen. Do not edit it manually!
+
+package v1alpha3
+
+import (
+	spec "github.com/go-openapi/spec"
+	common "k8s.io/kube-openapi/pkg/common"
+)
+
+func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
+	return map[string]common.OpenAPIDefinition{
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.BoundWorkload":                   schema_pkg_apis_servicebinding_v1alpha3_BoundWorkload(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.EnvMapping":                      schema_pkg_apis_servicebinding_v1alpha3_EnvMapping(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBinding":                  schema_pkg_apis_servicebinding_v1alpha3_ServiceBinding(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingMapping":           schema_pkg_apis_servicebinding_v1alpha3_ServiceBindingMapping(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingServiceReference":  schema_pkg_apis_servicebinding_v1alpha3_ServiceBindingServiceReference(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingSpec":              schema_pkg_apis_servicebinding_v1alpha3_ServiceBindingSpec(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingStatus":            schema_pkg_apis_servicebinding_v1alpha3_ServiceBindingStatus(ref),
+		"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingWorkloadReference": schema_pkg_apis_servicebinding_v1alpha3_ServiceBindingWorkloadReference(ref),
+	}
+}
+
+func schema_pkg_apis_servicebinding_v1alpha3_BoundWorkload(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BoundWorkload refers to a workload the binding has been projected into.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion is the API version of the workload.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is the kind of the workload.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the workload.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_servicebinding_v1alpha3_EnvMapping(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EnvMapping projects a binding entry as an environment variable.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the environment variable.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Key is the binding entry projected.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "key"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_servicebinding_v1alpha3_ServiceBinding(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceBinding expresses intent to bind a backing service with application workloads, following the servicebinding.io specification. It's handled the same way a ServiceBindingRequest is, having its binding projected as files.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingStatus"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingSpec", "github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_servicebinding_v1alpha3_ServiceBindingMapping(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceBindingMapping is an additional binding entry, whose value is a template.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the binding entry.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value is the template rendering the value of the binding entry.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "value"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_servicebinding_v1alpha3_ServiceBindingServiceReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceBindingServiceReference refers to the backing service, in the same namespace.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion is the API version of the service.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is the kind of the service.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the service.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"apiVersion", "kind", "name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_servicebinding_v1alpha3_ServiceBindingSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceBindingSpec defines the desired state of ServiceBinding",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the \"type\" entry of the binding, the lower-cased kind of the service by default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"provider": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Provider is the \"provider\" entry of the binding, the API group of the service by default.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"workload": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Workload is the reference to the application workloads the service is bound to, either by name or by label selector.",
+							Ref:         ref("github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingWorkloadReference"),
+						},
+					},
+					"service": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Service is the reference to the backing service.",
+							Ref:         ref("github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingServiceReference"),
+						},
+					},
+					"env": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Env projects binding entries as environment variables in the workload containers, besides projecting all of them as files.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.EnvMapping"),
+									},
+								},
+							},
+						},
+					},
+					"mappings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mappings are additional binding entries, whose values are templates evaluated the same way ServiceBindingRequest's \"customEnvVar\" are.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingMapping"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"workload", "service"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.EnvMapping", "github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingMapping", "github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingServiceReference", "github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.ServiceBindingWorkloadReference"},
+	}
+}
+
+func schema_pkg_apis_servicebinding_v1alpha3_ServiceBindingStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceBindingStatus defines the observed state of ServiceBinding",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the generation of the ServiceBinding the status refers to.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"conditions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions describes the state of the operator's reconciliation functionality.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/openshift/custom-resource-status/conditions/v1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"binding": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Binding is the secret holding the binding entries.",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+					"workloads": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Workloads are the workloads the binding has been projected into.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.BoundWorkload"),
+									},
+								},
+							},
+						},
+					},
+					"driftCount": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DriftCount is the number of times bound workloads were found missing binding items, which were then re-applied.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/openshift/custom-resource-status/conditions/v1.Condition", "github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3.BoundWorkload", "k8s.io/api/core/v1.LocalObjectReference"},
+	}
+}
+
+func schema_pkg_apis_servicebinding_v1alpha3_ServiceBindingWorkloadReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ServiceBindingWorkloadReference refers to the application workloads, by name or by label selector.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion is the API version of the workloads.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is the kind of the workloads.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the workload, taking precedence over the selector.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"selector": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Selector selects the workloads by labels.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+						},
+					},
+				},
+				Required: []string{"apiVersion", "kind"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+	}
+}
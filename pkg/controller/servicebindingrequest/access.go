@@ -0,0 +1,177 @@
+package servicebindingrequest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/converter"
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+// requesterAnnotation is the annotation recording, at admission, the user requesting a service
+// binding, whose permissions are checked before backing service resources are read.
+const requesterAnnotation = "service-binding-operator.apps.openshift.io/requester"
+
+// ReadAccessDeniedErr is returned when the user requesting the service binding is not allowed to
+// read a backing service.
+var ReadAccessDeniedErr = errors.New("read access denied")
+
+// unknownRequesterReason is the reason of reads denied when no requester is recorded.
+const unknownRequesterReason = "requester unknown"
+
+// subjectAccessReviewsGVR is the resource of subject access reviews.
+var subjectAccessReviewsGVR = authorizationv1.SchemeGroupVersion.WithResource("subjectaccessreviews")
+
+// readRequester returns the user recorded as requesting the informed object, and whether one is
+// recorded at all.
+func readRequester(obj metav1.Object) (*authenticationv1.UserInfo, bool, error) {
+	value, found := obj.GetAnnotations()[requesterAnnotation]
+	if !found {
+		return nil, false, nil
+	}
+	requester := &authenticationv1.UserInfo{}
+	if err := json.Unmarshal([]byte(value), requester); err != nil {
+		return nil, false, fmt.Errorf("unable to read '%s' annotation: %s", requesterAnnotation, err)
+	}
+	return requester, true, nil
+}
+
+// accessAttributes identifies a read of a resource.
+type accessAttributes struct {
+	verb      string
+	gr        schema.GroupResource
+	namespace string
+	name      string
+}
+
+// accessChecker checks, through subject access reviews, whether the user requesting a service
+// binding is allowed to read resources, keeping track of the denied reads. When no requester is
+// recorded, such as when the admission webhook is disabled, only reads in the namespace of the
+// service binding are allowed.
+type accessChecker struct {
+	client       dynamic.Interface               // kubernetes dynamic api client
+	sbr          *v1alpha1.ServiceBindingRequest // service binding request whose reads are checked
+	requester    *authenticationv1.UserInfo      // user requesting the service binding, when recorded
+	requesterErr error                           // error reading the requester, failing all checks
+	reviewed     map[accessAttributes]bool       // whether reads already reviewed are allowed
+	denied       []v1alpha1.DeniedRead           // reads denied so far
+	logger       *log.Log                        // logger instance
+}
+
+// newAccessChecker returns an accessChecker of the user recorded as requesting the informed SBR,
+// allowing all reads when no SBR is informed.
+func newAccessChecker(client dynamic.Interface, sbr *v1alpha1.ServiceBindingRequest) *accessChecker {
+	c := &accessChecker{
+		client:   client,
+		sbr:      sbr,
+		reviewed: make(map[accessAttributes]bool),
+		logger:   log.NewLog("access"),
+	}
+	if sbr != nil {
+		c.requester, _, c.requesterErr = readRequester(sbr)
+	}
+	return c
+}
+
+// requesterUnknown returns whether the SBR doesn't record its requester, whose permissions can't
+// be checked then.
+func (c *accessChecker) requesterUnknown() bool {
+	return c.sbr != nil && c.requester == nil && c.requesterErr == nil
+}
+
+// canRead returns whether the requester is allowed to perform the informed verb, "get" or "list",
+// on the informed resource, recording the read as denied otherwise.
+func (c *accessChecker) canRead(
+	verb string,
+	gvr schema.GroupVersionResource,
+	ns string,
+	name string,
+) (bool, error) {
+	if c.requesterErr != nil {
+		return false, c.requesterErr
+	}
+	if c.sbr == nil {
+		return true, nil
+	}
+	attrs := accessAttributes{verb: verb, gr: gvr.GroupResource(), namespace: ns, name: name}
+	if allowed, ok := c.reviewed[attrs]; ok {
+		return allowed, nil
+	}
+	if c.requester == nil {
+		// without a requester, the operator privileges are only lent within the SBR namespace
+		allowed := ns == c.sbr.GetNamespace()
+		c.reviewed[attrs] = allowed
+		if !allowed {
+			c.deny(verb, gvr, ns, name, unknownRequesterReason)
+		}
+		return allowed, nil
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(c.requester.Extra))
+	for k, v := range c.requester.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: authorizationv1.SchemeGroupVersion.String(),
+			Kind:       "SubjectAccessReview",
+		},
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: ns,
+				Verb:      verb,
+				Group:     gvr.Group,
+				Version:   gvr.Version,
+				Resource:  gvr.Resource,
+				Name:      name,
+			},
+			User:   c.requester.Username,
+			Groups: c.requester.Groups,
+			Extra:  extra,
+			UID:    c.requester.UID,
+		},
+	}
+	u, err := converter.ToUnstructured(review)
+	if err != nil {
+		return false, err
+	}
+	result, err := c.client.Resource(subjectAccessReviewsGVR).Create(u, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	allowed, _, err := unstructured.NestedBool(result.Object, "status", "allowed")
+	if err != nil {
+		return false, err
+	}
+	c.reviewed[attrs] = allowed
+	if allowed {
+		return true, nil
+	}
+
+	reason, _, _ := unstructured.NestedString(result.Object, "status", "reason")
+	c.logger.Info("Read denied to the requester",
+		"User", c.requester.Username, "Verb", verb, "GVR", gvr, "Namespace", ns, "Name", name)
+	c.deny(verb, gvr, ns, name, reason)
+	return false, nil
+}
+
+// deny records the informed read as denied.
+func (c *accessChecker) deny(verb string, gvr schema.GroupVersionResource, ns, name, reason string) {
+	c.denied = append(c.denied, v1alpha1.DeniedRead{
+		Verb:      verb,
+		Group:     gvr.Group,
+		Resource:  gvr.Resource,
+		Namespace: ns,
+		Name:      name,
+		Reason:    reason,
+	})
+}
@@ -0,0 +1,107 @@
+package servicebindingrequest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+// setRequester records the informed user as requesting the SBR.
+func setRequester(t *testing.T, sbr *v1alpha1.ServiceBindingRequest, username string) {
+	requester, err := json.Marshal(authenticationv1.UserInfo{Username: username})
+	require.NoError(t, err)
+	sbr.SetAnnotations(map[string]string{requesterAnnotation: string(requester)})
+}
+
+// allowReads makes subject access reviews of the informed user allowed, denying the others.
+func allowReads(client *fakedynamic.FakeDynamicClient, username string) {
+	client.PrependReactor("create", "subjectaccessreviews",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			u := action.(k8stesting.CreateAction).GetObject().(*unstructured.Unstructured).DeepCopy()
+			user, _, _ := unstructured.NestedString(u.Object, "spec", "user")
+			err := unstructured.SetNestedField(u.Object, user == username, "status", "allowed")
+			return true, u, err
+		})
+}
+
+func TestAccessCheckerCanRead(t *testing.T) {
+	ns := "access"
+	secretsGVR := corev1.SchemeGroupVersion.WithResource("secrets")
+
+	f := mocks.NewFake(t, ns)
+	fakeDynClient := f.FakeDynClient()
+	allowReads(fakeDynClient, "allowed")
+	sbr := mocks.ServiceBindingRequestMock(ns, "sbr", nil, "db", "app", deploymentsGVR, nil)
+
+	t.Run("no requester", func(t *testing.T) {
+		checker := newAccessChecker(fakeDynClient, sbr)
+		allowed, err := checker.canRead("get", secretsGVR, ns, "db-credentials")
+		require.NoError(t, err)
+		require.True(t, allowed)
+		require.Empty(t, checker.denied)
+
+		allowed, err = checker.canRead("get", secretsGVR, "other", "db-credentials")
+		require.NoError(t, err)
+		require.False(t, allowed)
+		require.Equal(t, []v1alpha1.DeniedRead{{
+			Verb:      "get",
+			Resource:  "secrets",
+			Namespace: "other",
+			Name:      "db-credentials",
+			Reason:    unknownRequesterReason,
+		}}, checker.denied)
+	})
+
+	t.Run("no service binding request", func(t *testing.T) {
+		checker := newAccessChecker(fakeDynClient, nil)
+		allowed, err := checker.canRead("get", secretsGVR, "other", "db-credentials")
+		require.NoError(t, err)
+		require.True(t, allowed)
+		require.Empty(t, checker.denied)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		s := sbr.DeepCopy()
+		setRequester(t, s, "allowed")
+		checker := newAccessChecker(fakeDynClient, s)
+		allowed, err := checker.canRead("get", secretsGVR, ns, "db-credentials")
+		require.NoError(t, err)
+		require.True(t, allowed)
+		require.Empty(t, checker.denied)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		s := sbr.DeepCopy()
+		setRequester(t, s, "denied")
+		checker := newAccessChecker(fakeDynClient, s)
+		for i := 0; i < 2; i++ {
+			allowed, err := checker.canRead("get", secretsGVR, ns, "db-credentials")
+			require.NoError(t, err)
+			require.False(t, allowed)
+		}
+		require.Equal(t, []v1alpha1.DeniedRead{{
+			Verb:      "get",
+			Resource:  "secrets",
+			Namespace: ns,
+			Name:      "db-credentials",
+		}}, checker.denied)
+	})
+
+	t.Run("invalid requester", func(t *testing.T) {
+		s := sbr.DeepCopy()
+		s.SetAnnotations(map[string]string{requesterAnnotation: "{"})
+		checker := newAccessChecker(fakeDynClient, s)
+		_, err := checker.canRead("get", secretsGVR, ns, "db-credentials")
+		require.Error(t, err)
+	})
+}
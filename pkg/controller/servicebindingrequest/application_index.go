@@ -0,0 +1,142 @@
+package servicebindingrequest
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+)
+
+// applicationSelection is what is needed from an application selector of a service binding request
+// to tell whether it selects, or has bound, a given application.
+type applicationSelection struct {
+	gvk          schema.GroupVersionKind       // application kind
+	namespace    string                        // application namespace, unless selected by labels
+	anyNamespace bool                          // whether application namespaces are selected by labels
+	resourceRef  string                        // application name, when selected by name
+	selector     labels.Selector               // application label selector, when selected by labels
+	bound        map[types.NamespacedName]bool // applications recorded as bound in status
+}
+
+// matches returns whether the selection selects, or has bound, the informed application. Namespaces
+// selected by labels aren't inspected, so applications in any namespace are matched by those.
+func (s *applicationSelection) matches(obj metav1.Object) bool {
+	if s.bound[types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}] {
+		return true
+	}
+	if !s.anyNamespace && s.namespace != obj.GetNamespace() {
+		return false
+	}
+	if s.resourceRef != "" {
+		return s.resourceRef == obj.GetName()
+	}
+	return s.selector != nil && s.selector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// applicationIndex is a reverse index from application objects to the service binding requests
+// selecting them, safe for concurrent use.
+type applicationIndex struct {
+	lock       sync.RWMutex
+	selections map[types.NamespacedName][]*applicationSelection
+}
+
+// newApplicationIndex returns an empty applicationIndex.
+func newApplicationIndex() *applicationIndex {
+	return &applicationIndex{selections: make(map[types.NamespacedName][]*applicationSelection)}
+}
+
+// newApplicationSelection returns the selection of the informed application selector of the SBR,
+// where gvk is the kind of its application resource.
+func newApplicationSelection(
+	sbr *v1alpha1.ServiceBindingRequest,
+	appSelector v1alpha1.ApplicationSelector,
+	gvk schema.GroupVersionKind,
+) (*applicationSelection, error) {
+	selection := &applicationSelection{
+		gvk:          gvk,
+		namespace:    appSelector.Namespace,
+		anyNamespace: appSelector.NamespaceSelector != nil,
+		resourceRef:  appSelector.ResourceRef,
+		bound:        make(map[types.NamespacedName]bool),
+	}
+	if selection.namespace == "" {
+		selection.namespace = sbr.GetNamespace()
+	}
+	if selection.resourceRef == "" && appSelector.LabelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(appSelector.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", InvalidApplicationLabelSelectorErr, err)
+		}
+		selection.selector = selector
+	}
+	for _, app := range sbr.Status.ApplicationObjects {
+		if app.Group == gvk.Group && app.Kind == gvk.Kind {
+			ns := app.Namespace
+			if ns == "" {
+				ns = sbr.GetNamespace()
+			}
+			selection.bound[types.NamespacedName{Namespace: ns, Name: app.Name}] = true
+		}
+	}
+	return selection, nil
+}
+
+// set indexes the application selectors of the informed SBR, where gvks are the kinds of their
+// application resources in the same order, replacing a previous entry. Selectors whose kind is
+// not informed are skipped.
+func (i *applicationIndex) set(sbr *v1alpha1.ServiceBindingRequest, gvks ...schema.GroupVersionKind) error {
+	selections := []*applicationSelection{}
+	for n, appSelector := range applicationSelectors(sbr) {
+		if n >= len(gvks) || gvks[n].Kind == "" {
+			continue
+		}
+		selection, err := newApplicationSelection(sbr, appSelector, gvks[n])
+		if err != nil {
+			return err
+		}
+		selections = append(selections, selection)
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.selections[types.NamespacedName{Namespace: sbr.GetNamespace(), Name: sbr.GetName()}] = selections
+	return nil
+}
+
+// delete removes the entry of the informed SBR.
+func (i *applicationIndex) delete(namespacedName types.NamespacedName) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	delete(i.selections, namespacedName)
+}
+
+// lookup returns the namespaced names of the SBRs selecting the informed application, or having it
+// recorded as bound, so it's also unbound when not selected anymore.
+func (i *applicationIndex) lookup(gvk schema.GroupVersionKind, obj metav1.Object) []types.NamespacedName {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	result := []types.NamespacedName{}
+	for namespacedName, selections := range i.selections {
+		for _, selection := range selections {
+			if selection.gvk.Group != gvk.Group || selection.gvk.Kind != gvk.Kind {
+				continue
+			}
+			if selection.matches(obj) {
+				result = append(result, namespacedName)
+				break
+			}
+		}
+	}
+	// map iteration order is random, sorting keeps results stable
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].String() < result[b].String()
+	})
+	return result
+}
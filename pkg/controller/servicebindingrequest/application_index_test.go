@@ -0,0 +1,124 @@
+package servicebindingrequest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestApplicationIndex(t *testing.T) {
+	ns := "application-index"
+	matchLabels := map[string]string{"connects-to": "database"}
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	// application returns an application object with the informed name and labels.
+	application := func(namespace, name string, labels map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(deploymentGVK)
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		u.SetLabels(labels)
+		return u
+	}
+
+	byLabels := mocks.ServiceBindingRequestMock(ns, "by-labels", nil, "db", "", deploymentsGVR, matchLabels)
+	byName := mocks.ServiceBindingRequestMock(ns, "by-name", nil, "db", "app", deploymentsGVR, nil)
+	byLabelsNN := types.NamespacedName{Namespace: ns, Name: "by-labels"}
+	byNameNN := types.NamespacedName{Namespace: ns, Name: "by-name"}
+
+	index := newApplicationIndex()
+	require.NoError(t, index.set(byLabels, deploymentGVK))
+	require.NoError(t, index.set(byName, deploymentGVK))
+
+	t.Run("selected by labels and name", func(t *testing.T) {
+		got := index.lookup(deploymentGVK, application(ns, "app", matchLabels))
+		require.Equal(t, []types.NamespacedName{byLabelsNN, byNameNN}, got)
+	})
+
+	t.Run("selected by labels", func(t *testing.T) {
+		got := index.lookup(deploymentGVK, application(ns, "other", matchLabels))
+		require.Equal(t, []types.NamespacedName{byLabelsNN}, got)
+	})
+
+	t.Run("not selected", func(t *testing.T) {
+		require.Empty(t, index.lookup(deploymentGVK, application(ns, "other", nil)))
+		require.Empty(t, index.lookup(deploymentGVK, application("other-ns", "app", matchLabels)))
+		otherGVK := schema.GroupVersionKind{Group: "apps.openshift.io", Version: "v1", Kind: "DeploymentConfig"}
+		require.Empty(t, index.lookup(otherGVK, application(ns, "app", matchLabels)))
+	})
+
+	t.Run("unselected while bound", func(t *testing.T) {
+		bound := byLabels.DeepCopy()
+		bound.Status.ApplicationObjects = []v1alpha1.BoundApplication{{
+			GroupVersionKind:     metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			LocalObjectReference: corev1.LocalObjectReference{Name: "other"},
+		}}
+		require.NoError(t, index.set(bound, deploymentGVK))
+		got := index.lookup(deploymentGVK, application(ns, "other", nil))
+		require.Equal(t, []types.NamespacedName{byLabelsNN}, got)
+	})
+
+	t.Run("other namespace", func(t *testing.T) {
+		other := byName.DeepCopy()
+		other.Spec.ApplicationSelector.Namespace = "tenant"
+		require.NoError(t, index.set(other, deploymentGVK))
+		defer func() { require.NoError(t, index.set(byName, deploymentGVK)) }()
+
+		require.Equal(t, []types.NamespacedName{byNameNN}, index.lookup(deploymentGVK, application("tenant", "app", nil)))
+		require.Equal(t, []types.NamespacedName{byLabelsNN}, index.lookup(deploymentGVK, application(ns, "app", matchLabels)))
+	})
+
+	t.Run("namespace selector", func(t *testing.T) {
+		other := byName.DeepCopy()
+		other.Spec.ApplicationSelector.NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"tenant": "true"},
+		}
+		require.NoError(t, index.set(other, deploymentGVK))
+		defer func() { require.NoError(t, index.set(byName, deploymentGVK)) }()
+
+		require.Equal(t, []types.NamespacedName{byNameNN}, index.lookup(deploymentGVK, application("tenant", "app", nil)))
+		require.Empty(t, index.lookup(deploymentGVK, application("tenant", "other", nil)))
+	})
+
+	t.Run("multiple selectors", func(t *testing.T) {
+		cronJobGVK := schema.GroupVersionKind{Group: "batch", Version: "v1beta1", Kind: "CronJob"}
+		multiple := byName.DeepCopy()
+		multiple.Spec.ApplicationSelectors = &[]v1alpha1.ApplicationSelector{{
+			GroupVersionResource: metav1.GroupVersionResource{Group: "batch", Version: "v1beta1", Resource: "cronjobs"},
+			ResourceRef:          "job",
+		}}
+		require.NoError(t, index.set(multiple, deploymentGVK, cronJobGVK))
+		defer func() { require.NoError(t, index.set(byName, deploymentGVK)) }()
+
+		require.Equal(t, []types.NamespacedName{byNameNN}, index.lookup(deploymentGVK, application(ns, "app", nil)))
+		job := application(ns, "job", nil)
+		job.SetGroupVersionKind(cronJobGVK)
+		require.Equal(t, []types.NamespacedName{byNameNN}, index.lookup(cronJobGVK, job))
+		require.Empty(t, index.lookup(deploymentGVK, application(ns, "job", nil)))
+	})
+
+	t.Run("invalid label selector", func(t *testing.T) {
+		invalid := byLabels.DeepCopy()
+		invalid.Spec.ApplicationSelector.LabelSelector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "connects-to", Operator: "Unknown"}},
+		}
+		err := index.set(invalid, deploymentGVK)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, InvalidApplicationLabelSelectorErr))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		index.delete(byLabelsNN)
+		index.delete(byNameNN)
+		require.Empty(t, index.lookup(deploymentGVK, application(ns, "app", matchLabels)))
+	})
+}
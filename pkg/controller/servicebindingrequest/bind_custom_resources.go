@@ -26,6 +26,7 @@ type DetectBindableResources struct {
 	resourcesToCheck []schema.GroupVersionResource
 	client           dynamic.Interface
 	data             map[string]interface{}
+	access           *accessChecker
 }
 
 // NewDetectBindableResources returns new instance
@@ -40,6 +41,7 @@ func NewDetectBindableResources(
 	b.cr = cr
 	b.resourcesToCheck = resources
 	b.data = make(map[string]interface{})
+	b.access = newAccessChecker(client, sbr)
 	return b
 }
 
@@ -47,6 +49,14 @@ func NewDetectBindableResources(
 func (b DetectBindableResources) GetOwnedResources() ([]unstructured.Unstructured, error) {
 	var subResources []unstructured.Unstructured
 	for _, resource := range b.resourcesToCheck {
+		// resources the requester can't list are skipped, being recorded as denied reads
+		allowed, err := b.access.canRead("list", resource, b.cr.GetNamespace(), "")
+		if err != nil {
+			return subResources, err
+		}
+		if !allowed {
+			continue
+		}
 		lst, err := b.client.Resource(resource).Namespace(b.cr.GetNamespace()).List(v1.ListOptions{})
 		if err != nil {
 			return subResources, err
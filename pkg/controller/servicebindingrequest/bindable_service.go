@@ -0,0 +1,121 @@
+package servicebindingrequest
+
+import (
+	"strings"
+
+	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+)
+
+// bindableServiceGVR is the resource of the cluster-scoped BindableServices.
+var bindableServiceGVR = v1alpha1.SchemeGroupVersion.WithResource(BindableServiceResource)
+
+// selectBindableService returns the CRDDescription built from the BindableService describing the
+// informed kind, or nil when no BindableService describes it. BindableServices not informing a
+// version describe all versions of the kind, those informing the version are preferred.
+func selectBindableService(
+	client dynamic.Interface,
+	gvk schema.GroupVersionKind,
+) (*olmv1alpha1.CRDDescription, error) {
+	list, err := client.Resource(bindableServiceGVR).List(metav1.ListOptions{})
+	// the BindableService CRD may not be installed
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var selected *v1alpha1.BindableService
+	for i := range list.Items {
+		bs := &v1alpha1.BindableService{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, bs); err != nil {
+			return nil, err
+		}
+		if bs.Spec.Group != gvk.Group || !strings.EqualFold(bs.Spec.Kind, gvk.Kind) {
+			continue
+		}
+		if bs.Spec.Version == "" && selected == nil {
+			selected = bs
+		} else if strings.EqualFold(bs.Spec.Version, gvk.Version) {
+			selected = bs
+			break
+		}
+	}
+	if selected == nil {
+		return nil, nil
+	}
+
+	crdDescription := &olmv1alpha1.CRDDescription{
+		Name:    selected.GetName(),
+		Version: selected.Spec.Version,
+		Kind:    selected.Spec.Kind,
+	}
+	for _, d := range selected.Spec.SpecDescriptors {
+		crdDescription.SpecDescriptors = append(crdDescription.SpecDescriptors, olmv1alpha1.SpecDescriptor{
+			Path:         d.Path,
+			XDescriptors: d.XDescriptors,
+		})
+	}
+	for _, d := range selected.Spec.StatusDescriptors {
+		crdDescription.StatusDescriptors = append(crdDescription.StatusDescriptors, olmv1alpha1.StatusDescriptor{
+			Path:         d.Path,
+			XDescriptors: d.XDescriptors,
+		})
+	}
+	return crdDescription, nil
+}
+
+// mergeCRDDescriptions merges the informed CRDDescriptions, given in order of precedence. Their
+// descriptors are combined, and descriptors of a field path already described by a preceding
+// CRDDescription are ignored, while all descriptors of the same CRDDescription are kept, such as UI
+// and binding descriptors of the same path. A single CRDDescription is returned untouched.
+func mergeCRDDescriptions(crdDescriptions ...*olmv1alpha1.CRDDescription) *olmv1alpha1.CRDDescription {
+	var informed []*olmv1alpha1.CRDDescription
+	for _, d := range crdDescriptions {
+		if d != nil {
+			informed = append(informed, d)
+		}
+	}
+	switch len(informed) {
+	case 0:
+		return nil
+	case 1:
+		return informed[0]
+	}
+
+	first := informed[0]
+	merged := &olmv1alpha1.CRDDescription{Name: first.Name, Version: first.Version, Kind: first.Kind}
+	specPaths := make(map[string]bool)
+	statusPaths := make(map[string]bool)
+	for _, d := range informed {
+		// paths are only skipped when described by a preceding CRDDescription
+		describedSpecPaths := make(map[string]bool)
+		for _, s := range d.SpecDescriptors {
+			if !specPaths[s.Path] {
+				describedSpecPaths[s.Path] = true
+				merged.SpecDescriptors = append(merged.SpecDescriptors, s)
+			}
+		}
+		describedStatusPaths := make(map[string]bool)
+		for _, s := range d.StatusDescriptors {
+			if !statusPaths[s.Path] {
+				describedStatusPaths[s.Path] = true
+				merged.StatusDescriptors = append(merged.StatusDescriptors, s)
+			}
+		}
+		for p := range describedSpecPaths {
+			specPaths[p] = true
+		}
+		for p := range describedStatusPaths {
+			statusPaths[p] = true
+		}
+	}
+	return merged
+}
@@ -0,0 +1,108 @@
+package servicebindingrequest
+
+import (
+	"context"
+	"testing"
+
+	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestSelectBindableService(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: mocks.CRDName, Version: mocks.CRDVersion, Kind: mocks.CRDKind}
+
+	t.Run("none", func(t *testing.T) {
+		f := mocks.NewFake(t, "bindable-service")
+		f.AddMockedUnstructuredBindableService("other", "v1")
+		crdDescription, err := selectBindableService(f.FakeDynClient(), gvk)
+		require.NoError(t, err)
+		require.Nil(t, crdDescription)
+	})
+
+	t.Run("all versions", func(t *testing.T) {
+		f := mocks.NewFake(t, "bindable-service")
+		f.AddMockedUnstructuredBindableService("all", "")
+		crdDescription, err := selectBindableService(f.FakeDynClient(), gvk)
+		require.NoError(t, err)
+		require.NotNil(t, crdDescription)
+		require.Equal(t, "all", crdDescription.Name)
+		require.Equal(t, []olmv1alpha1.SpecDescriptor{
+			{Path: "image", XDescriptors: []string{"binding:env:attribute"}},
+		}, crdDescription.SpecDescriptors)
+		require.Equal(t, []olmv1alpha1.StatusDescriptor{
+			{Path: "dbCredentials", XDescriptors: []string{"binding:env:object:secret:user"}},
+		}, crdDescription.StatusDescriptors)
+	})
+
+	t.Run("version preferred", func(t *testing.T) {
+		f := mocks.NewFake(t, "bindable-service")
+		f.AddMockedUnstructuredBindableService("all", "")
+		f.AddMockedUnstructuredBindableService("versioned", mocks.CRDVersion)
+		crdDescription, err := selectBindableService(f.FakeDynClient(), gvk)
+		require.NoError(t, err)
+		require.Equal(t, "versioned", crdDescription.Name)
+	})
+}
+
+func TestMergeCRDDescriptions(t *testing.T) {
+	bindableService := &olmv1alpha1.CRDDescription{
+		Name:              "bindable-service",
+		StatusDescriptors: []olmv1alpha1.StatusDescriptor{{Path: "dbCredentials", XDescriptors: []string{"a"}}},
+	}
+	csv := &olmv1alpha1.CRDDescription{
+		Name:              "csv",
+		SpecDescriptors:   []olmv1alpha1.SpecDescriptor{{Path: "dbName", XDescriptors: []string{"b"}}},
+		StatusDescriptors: []olmv1alpha1.StatusDescriptor{{Path: "dbCredentials", XDescriptors: []string{"c"}}},
+	}
+	annotations := &olmv1alpha1.CRDDescription{
+		Name:            "crd",
+		SpecDescriptors: []olmv1alpha1.SpecDescriptor{{Path: "dbName", XDescriptors: []string{"d"}}, {Path: "image"}},
+	}
+
+	require.Nil(t, mergeCRDDescriptions(nil, nil))
+	require.Equal(t, &olmv1alpha1.CRDDescription{
+		Name:              "bindable-service",
+		SpecDescriptors:   []olmv1alpha1.SpecDescriptor{{Path: "dbName", XDescriptors: []string{"b"}}, {Path: "image"}},
+		StatusDescriptors: []olmv1alpha1.StatusDescriptor{{Path: "dbCredentials", XDescriptors: []string{"a"}}},
+	}, mergeCRDDescriptions(bindableService, csv, annotations))
+	require.Equal(t, csv, mergeCRDDescriptions(nil, csv, nil))
+
+	// descriptors of the same path in a single source are all kept, such as UI and binding ones
+	duplicated := &olmv1alpha1.CRDDescription{
+		Name: "csv",
+		StatusDescriptors: []olmv1alpha1.StatusDescriptor{
+			{Path: "dbCredentials", XDescriptors: []string{"urn:alm:descriptor:io.kubernetes:Secret"}},
+			{Path: "dbCredentials", XDescriptors: []string{"binding:env:object:secret:password"}},
+		},
+	}
+	require.Equal(t, duplicated, mergeCRDDescriptions(nil, duplicated, nil))
+	require.Equal(t, &olmv1alpha1.CRDDescription{
+		Name:              "csv",
+		SpecDescriptors:   []olmv1alpha1.SpecDescriptor{{Path: "dbName", XDescriptors: []string{"d"}}, {Path: "image"}},
+		StatusDescriptors: duplicated.StatusDescriptors,
+	}, mergeCRDDescriptions(duplicated, annotations))
+	require.Equal(t, &olmv1alpha1.CRDDescription{
+		Name:              "bindable-service",
+		StatusDescriptors: bindableService.StatusDescriptors,
+	}, mergeCRDDescriptions(bindableService, duplicated))
+}
+
+// TestPlannerBindableService checks backing services are planned with descriptors declared only by
+// a BindableService, the CRD being neither annotated nor described by a CSV.
+func TestPlannerBindableService(t *testing.T) {
+	ns := "bindable-service"
+	f := mocks.NewFake(t, ns)
+	sbr := f.AddMockedServiceBindingRequest("sbr", nil, "db", "", deploymentsGVR, nil)
+	f.AddMockedUnstructuredDatabaseCR("db")
+	crd := f.AddMockedUnstructuredDatabaseCRD()
+	crd.SetAnnotations(nil)
+	f.AddMockedUnstructuredBindableService("databases", "")
+
+	plan, err := NewPlanner(context.TODO(), f.FakeDynClient(), f.FakeRESTMapper(), sbr).Plan()
+	require.NoError(t, err)
+	require.Len(t, plan.RelatedResources, 1)
+	require.Equal(t, "databases", plan.RelatedResources[0].CRDDescription.Name)
+}
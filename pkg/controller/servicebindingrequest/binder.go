@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
+	"reflect"
+	"sort"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"gotest.tools/assert/cmp"
@@ -25,135 +27,327 @@ import (
 )
 
 var (
-	// containersPath logical path to find containers on supported objects
+	// containersPath logical path to find containers on workloads having a pod template
 	containersPath = []string{"spec", "template", "spec", "containers"}
-	// volumesPath logical path to find volumes on supported objects
+	// volumesPath logical path to find volumes on workloads having a pod template
 	volumesPath = []string{"spec", "template", "spec", "volumes"}
 )
 
-// ChangeTriggerEnv hijacking environment in order to trigger a change
+// ChangeTriggerEnv hijacking environment in order to trigger a change, used by the environment
+// variable restart strategy
 const ChangeTriggerEnv = "ServiceBindingOperatorChangeTriggerEnvVar"
 
 // Binder executes the "binding" act of updating different application kinds to use intermediary
 // secret. Those secrets should be offered as environment variables.
 type Binder struct {
-	ctx        context.Context                 // request context
-	client     client.Client                   // kubernetes API client
-	dynClient  dynamic.Interface               // kubernetes dynamic api client
-	sbr        *v1alpha1.ServiceBindingRequest // instantiated service binding request
-	volumeKeys []string                        // list of key names used in volume mounts
-	logger     *log.Log                        // logger instance
+	ctx          context.Context                 // request context
+	client       client.Client                   // kubernetes API client
+	dynClient    dynamic.Interface               // kubernetes dynamic api client
+	restMapper   meta.RESTMapper                 // maps kinds to resources
+	sbr          *v1alpha1.ServiceBindingRequest // instantiated service binding request
+	volumeKeys   []VolumeKey                     // intermediary secret keys projected as files
+	secretKeyEnv []corev1.EnvVar                 // environment variables referring to secret keys
+	dataHash     string                          // hash of the intermediary secret data
+	drifts       []string                        // bound objects found missing binding items
+	skipped      []string                        // immutable workloads selected but not bound
+	logger       *log.Log                        // logger instance
+
+	// groups are the applications found by each application selector during the last bind
+	groups []applicationGroup
+	// bindingPaths are the binding paths of the selectors the applications were found by
+	bindingPaths map[objectReference]*v1alpha1.BindingPath
+}
+
+// applicationGroup is the applications found by one of the SBR application selectors.
+type applicationGroup struct {
+	selector v1alpha1.ApplicationSelector // application selector
+	refs     []objectReference            // applications found by the selector
 }
 
 var EmptyApplicationSelectorErr = errors.New("application ResourceRef or MatchLabel not found")
 
-// search objects based in Kind/APIVersion, which contain the labels defined in ApplicationSelector.
-func (b *Binder) search() (*unstructured.UnstructuredList, error) {
-	ns := b.sbr.GetNamespace()
+// InvalidApplicationLabelSelectorErr is returned when the application label selector can't be
+// converted to a selector, for instance due to unsupported operators or invalid values.
+var InvalidApplicationLabelSelectorErr = errors.New("application label selector is invalid")
+
+// applicationSelectors returns the application selectors of the informed SBR, "applicationSelector"
+// first when informed, followed by "applicationSelectors". The former is returned, even if empty,
+// when the latter is empty.
+func applicationSelectors(sbr *v1alpha1.ServiceBindingRequest) []v1alpha1.ApplicationSelector {
+	if sbr.Spec.ApplicationSelectors == nil || len(*sbr.Spec.ApplicationSelectors) == 0 {
+		return []v1alpha1.ApplicationSelector{sbr.Spec.ApplicationSelector}
+	}
+	var selectors []v1alpha1.ApplicationSelector
+	if isInformedApplicationSelector(sbr.Spec.ApplicationSelector) {
+		selectors = append(selectors, sbr.Spec.ApplicationSelector)
+	}
+	return append(selectors, *sbr.Spec.ApplicationSelectors...)
+}
+
+// isInformedApplicationSelector returns whether any field of the informed selector is set.
+func isInformedApplicationSelector(selector v1alpha1.ApplicationSelector) bool {
+	return !reflect.DeepEqual(selector, v1alpha1.ApplicationSelector{})
+}
+
+// namespacedName returns the namespaced name of the SBR, identifying its injections in workloads.
+func (b *Binder) namespacedName() types.NamespacedName {
+	return types.NamespacedName{Namespace: b.sbr.GetNamespace(), Name: b.sbr.GetName()}
+}
+
+// search objects based in Kind/APIVersion, which contain the labels defined in the informed
+// application selector, in the application namespaces, recording the selector binding path of the
+// objects found.
+func (b *Binder) search(appSelector v1alpha1.ApplicationSelector) (*unstructured.UnstructuredList, error) {
 	gvr := schema.GroupVersionResource{
-		Group:    b.sbr.Spec.ApplicationSelector.GroupVersionResource.Group,
-		Version:  b.sbr.Spec.ApplicationSelector.GroupVersionResource.Version,
-		Resource: b.sbr.Spec.ApplicationSelector.GroupVersionResource.Resource,
+		Group:    appSelector.GroupVersionResource.Group,
+		Version:  appSelector.GroupVersionResource.Version,
+		Resource: appSelector.GroupVersionResource.Resource,
 	}
 
 	var opts metav1.ListOptions
 
 	// If Application name is present
-	if b.sbr.Spec.ApplicationSelector.ResourceRef != "" {
+	if appSelector.ResourceRef != "" {
 		fieldName := make(map[string]string)
-		fieldName["metadata.name"] = b.sbr.Spec.ApplicationSelector.ResourceRef
+		fieldName["metadata.name"] = appSelector.ResourceRef
 		opts = metav1.ListOptions{
 			FieldSelector: fields.Set(fieldName).String(),
 		}
-	} else if b.sbr.Spec.ApplicationSelector.LabelSelector != nil {
-		matchLabels := b.sbr.Spec.ApplicationSelector.LabelSelector.MatchLabels
+	} else if appSelector.LabelSelector != nil {
+		// both matchLabels and matchExpressions are taken into account
+		selector, err := metav1.LabelSelectorAsSelector(appSelector.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", InvalidApplicationLabelSelectorErr, err)
+		}
 		opts = metav1.ListOptions{
-			LabelSelector: labels.Set(matchLabels).String(),
+			LabelSelector: selector.String(),
 		}
 	} else {
 		return nil, EmptyApplicationSelectorErr
 	}
 
-	objList, err := b.dynClient.Resource(gvr).Namespace(ns).List(opts)
+	namespaces, err := b.applicationNamespaces(appSelector)
 	if err != nil {
 		return nil, err
 	}
 
+	objList := &unstructured.UnstructuredList{}
+	for _, ns := range namespaces {
+		nsList, err := b.dynClient.Resource(gvr).Namespace(ns).List(opts)
+		if err != nil {
+			return nil, err
+		}
+		objList.Items = append(objList.Items, nsList.Items...)
+	}
+
 	// Return fake NotFound error explicitly to ensure requeue when objList(^) is empty.
 	if len(objList.Items) == 0 {
 		return nil, k8serror.NewNotFound(
 			gvr.GroupResource(),
-			b.sbr.Spec.ApplicationSelector.GroupVersionResource.Resource,
+			appSelector.GroupVersionResource.Resource,
 		)
 	}
-	return objList, err
+
+	// objects found by more than one selector keep the binding path of the first one
+	for i := range objList.Items {
+		ref := objectReferenceFor(objList.Items[i].GroupVersionKind(), &objList.Items[i])
+		if _, found := b.bindingPaths[ref]; !found {
+			b.bindingPaths[ref] = appSelector.BindingPath
+		}
+	}
+	return objList, nil
+}
+
+// searchAll searches the objects of every application selector of the SBR, recording the ones found
+// by each. Selectors finding no objects, or whose namespace doesn't allow bindings, don't prevent
+// the objects found by the others from being bound, while a namespace not allowing bindings is
+// still returned as an error along with those.
+func (b *Binder) searchAll() (*unstructured.UnstructuredList, error) {
+	b.groups = nil
+	b.bindingPaths = make(map[objectReference]*v1alpha1.BindingPath)
+
+	var notFoundErr, notAllowedErr error
+	found := make(map[objectReference]bool)
+	objList := &unstructured.UnstructuredList{}
+	for _, selector := range applicationSelectors(b.sbr) {
+		group := applicationGroup{selector: selector}
+		list, err := b.search(selector)
+		if k8serror.IsNotFound(err) {
+			notFoundErr = err
+			list = &unstructured.UnstructuredList{}
+		} else if errors.Is(err, ApplicationNamespaceNotAllowedErr) {
+			notAllowedErr = err
+			list = &unstructured.UnstructuredList{}
+		} else if err != nil {
+			return nil, err
+		}
+		for _, obj := range list.Items {
+			ref := objectReferenceFor(obj.GroupVersionKind(), &obj)
+			group.refs = append(group.refs, ref)
+			if !found[ref] {
+				found[ref] = true
+				objList.Items = append(objList.Items, obj)
+			}
+		}
+		b.groups = append(b.groups, group)
+	}
+
+	if len(objList.Items) == 0 {
+		if notAllowedErr != nil {
+			return nil, notAllowedErr
+		}
+		return nil, notFoundErr
+	}
+	return objList, notAllowedErr
+}
+
+// bindingPath returns the binding path of the application selector the informed object was found
+// by.
+func (b *Binder) bindingPath(obj *unstructured.Unstructured) *v1alpha1.BindingPath {
+	return b.bindingPaths[objectReferenceFor(obj.GroupVersionKind(), obj)]
+}
+
+// searchBound returns the application objects recorded as bound in the SBR status, skipping the
+// ones that don't exist anymore. Unlike search, it doesn't depend on the application selector.
+func (b *Binder) searchBound() (*unstructured.UnstructuredList, error) {
+	objList := &unstructured.UnstructuredList{}
+	for _, app := range b.sbr.Status.ApplicationObjects {
+		ns := app.Namespace
+		if ns == "" {
+			ns = b.sbr.GetNamespace()
+		}
+		gvk := schema.GroupVersionKind{Group: app.Group, Version: app.Version, Kind: app.Kind}
+		log := b.logger.WithValues("Obj.GVK", gvk, "Obj.Namespace", ns, "Obj.Name", app.Name)
+		gvr, err := resourceForKind(b.restMapper, gvk)
+		if errors.Is(err, UnknownKindErr) {
+			log.Debug("Bound object kind is not served anymore, skipping it")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		obj, err := b.dynClient.Resource(gvr).Namespace(ns).Get(app.Name, metav1.GetOptions{})
+		if k8serror.IsNotFound(err) {
+			log.Debug("Bound object is not found, skipping it")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		objList.Items = append(objList.Items, *obj)
+	}
+	return objList, nil
 }
 
-// extractSpecVolumes based on volume path, extract it unstructured. It can return error on trying
-// to find data in informed Unstructured object.
-func (b *Binder) extractSpecVolumes(obj *unstructured.Unstructured) ([]interface{}, error) {
-	log := b.logger.WithValues("Volumes.NestedPath", volumesPath)
+// extractSpecVolumes based on the volume path located by the informed adapter, extract it
+// unstructured. It can return error on trying to find data in informed Unstructured object.
+func (b *Binder) extractSpecVolumes(obj *unstructured.Unstructured, adapter WorkloadAdapter) ([]interface{}, error) {
+	log := b.logger.WithValues("Volumes.NestedPath", adapter.VolumesPath())
 	log.Debug("Reading volumes definitions...")
-	volumes, _, err := unstructured.NestedSlice(obj.Object, volumesPath...)
+	volumes, _, err := unstructured.NestedSlice(obj.Object, adapter.VolumesPath()...)
 	if err != nil {
 		return nil, err
 	}
 	return volumes, nil
 }
 
-// updateSpecVolumes execute the inspection and update "volumes" entries in informed spec.
+// updateSpecVolumes execute the inspection and update "volumes" entries in informed spec, recording
+// injected volumes.
 func (b *Binder) updateSpecVolumes(
 	obj *unstructured.Unstructured,
+	injected *injections,
 ) (*unstructured.Unstructured, error) {
-	volumes, err := b.extractSpecVolumes(obj)
+	adapter := injected.adapter(obj)
+	volumes, err := b.extractSpecVolumes(obj, adapter)
 	if err != nil {
 		return nil, err
 	}
 
-	volumes, err = b.updateVolumes(volumes)
+	volumes, err = b.updateVolumes(volumes, injected)
 	if err != nil {
 		return nil, err
 	}
-	if err = unstructured.SetNestedSlice(obj.Object, volumes, volumesPath...); err != nil {
+	if err = unstructured.SetNestedSlice(obj.Object, volumes, adapter.VolumesPath()...); err != nil {
 		return nil, err
 	}
 	return obj, nil
 }
 
-// removeSpecVolumes based on extract volume subset, removing volume bind volume entry. It can return
+// removeSpecVolumes based on extract volume subset, removing injected volume entries. It can return
 // error on navigating though unstructured object, or in the case of having issues to edit
 // unstructured resource.
 func (b *Binder) removeSpecVolumes(
 	obj *unstructured.Unstructured,
+	injected *injections,
 ) (*unstructured.Unstructured, error) {
-	volumes, err := b.extractSpecVolumes(obj)
+	adapter := injected.adapter(obj)
+	volumes, err := b.extractSpecVolumes(obj, adapter)
 	if err != nil {
 		return nil, err
 	}
-	volumes = b.removeVolumes(volumes)
-	if err = unstructured.SetNestedSlice(obj.Object, volumes, volumesPath...); err != nil {
+	if len(volumes) == 0 {
+		return obj, nil
+	}
+	volumes = b.removeVolumes(volumes, injected.Volumes)
+	if err = unstructured.SetNestedSlice(obj.Object, volumes, adapter.VolumesPath()...); err != nil {
 		return nil, err
 	}
 	return obj, nil
 }
 
-// updateVolumes inspect informed list assuming as []corev1.Volume, and if binding volume is already
-// defined just return the same list, otherwise, appending the binding volume.
-func (b *Binder) updateVolumes(volumes []interface{}) ([]interface{}, error) {
+// volumeName returns the name of the informed unstructured volume.
+func volumeName(volume interface{}) string {
+	u, ok := volume.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	name, _, _ := unstructured.NestedString(u, "name")
+	return name
+}
+
+// needsVolume returns whether the intermediary secret is projected as a volume, either when binding
+// as files or when some of the binding data is meant to be mounted.
+func (b *Binder) needsVolume() bool {
+	return b.sbr.Spec.BindAsFiles || len(b.volumeKeys) > 0
+}
+
+// volumeMountPath returns the path the binding volume is mounted on when not binding as files.
+func (b *Binder) volumeMountPath() string {
+	if b.sbr.Spec.MountPathPrefix != "" {
+		return b.sbr.Spec.MountPathPrefix
+	}
+	return "/var/data"
+}
+
+// updateVolumes inspect informed list of unstructured volumes, and if binding volume is already
+// defined and not injected by the operator just return the same list, otherwise, appending or
+// replacing the binding volume and recording it. When binding as files, all the binding data is
+// projected, otherwise only the items meant to be mounted.
+func (b *Binder) updateVolumes(volumes []interface{}, injected *injections) ([]interface{}, error) {
 	name := b.sbr.GetName()
 	log := b.logger
 	log.Debug("Checking if binding volume is already defined...")
-	for _, v := range volumes {
-		volume := v.(corev1.Volume)
-		if name == volume.Name {
-			log.Debug("Volume is already defined!")
-			return volumes, nil
+	idx := -1
+	for i, v := range volumes {
+		if name == volumeName(v) {
+			if !containsStringSlice(injected.Volumes, name) {
+				log.Debug("Volume is already defined!")
+				return volumes, nil
+			}
+			idx = i
 		}
 	}
+	injected.Volumes = appendInjected(injected.Volumes, name, false)
 
-	items := []corev1.KeyToPath{}
-	for _, k := range b.volumeKeys {
-		items = append(items, corev1.KeyToPath{Key: k, Path: k})
+	var items []corev1.KeyToPath
+	if !b.sbr.Spec.BindAsFiles {
+		items = []corev1.KeyToPath{}
+		for _, k := range b.volumeKeys {
+			items = append(items, corev1.KeyToPath{Key: k.Key, Path: k.Path, Mode: k.Mode})
+		}
+		// keeping the items in a stable order, so the workload only changes when they do
+		sort.Slice(items, func(i, j int) bool { return items[i].Path < items[j].Path })
 	}
 
 	log.Debug("Appending new volume with items.", "Items", items)
@@ -172,24 +366,28 @@ func (b *Binder) updateVolumes(volumes []interface{}) ([]interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if idx >= 0 {
+		// replacing the volume injected before, since the projected items may have changed
+		volumes[idx] = u
+		return volumes, nil
+	}
 	return append(volumes, u), nil
 }
 
-// removeVolumes remove the bind volumes from informed list of unstructured volumes.
-func (b *Binder) removeVolumes(volumes []interface{}) []interface{} {
-	name := b.sbr.GetName()
+// removeVolumes remove the informed volume names from the list of unstructured volumes.
+func (b *Binder) removeVolumes(volumes []interface{}, names []string) []interface{} {
 	var cleanVolumes []interface{}
 	for _, v := range volumes {
-		volume := v.(corev1.Volume)
-		if name != volume.Name {
+		if !containsStringSlice(names, volumeName(v)) {
 			cleanVolumes = append(cleanVolumes, v)
 		}
 	}
 	return cleanVolumes
 }
 
-// extractSpecContainers search for
-func (b *Binder) extractSpecContainers(obj *unstructured.Unstructured) ([]interface{}, error) {
+// extractSpecContainers search for containers in the path located by the informed adapter.
+func (b *Binder) extractSpecContainers(obj *unstructured.Unstructured, adapter WorkloadAdapter) ([]interface{}, error) {
+	containersPath := adapter.ContainersPath()
 	log := b.logger.WithValues("Containers.NestedPath", containersPath)
 
 	containers, found, err := unstructured.NestedSlice(obj.Object, containersPath...)
@@ -208,15 +406,17 @@ func (b *Binder) extractSpecContainers(obj *unstructured.Unstructured) ([]interf
 // updateSpecContainers extract containers from object, and trigger update.
 func (b *Binder) updateSpecContainers(
 	obj *unstructured.Unstructured,
+	injected *injections,
 ) (*unstructured.Unstructured, error) {
-	containers, err := b.extractSpecContainers(obj)
+	adapter := injected.adapter(obj)
+	containers, err := b.extractSpecContainers(obj, adapter)
 	if err != nil {
 		return nil, err
 	}
-	if containers, err = b.updateContainers(containers); err != nil {
+	if containers, err = b.updateContainers(containers, injected); err != nil {
 		return nil, err
 	}
-	if err = unstructured.SetNestedSlice(obj.Object, containers, containersPath...); err != nil {
+	if err = unstructured.SetNestedSlice(obj.Object, containers, adapter.ContainersPath()...); err != nil {
 		return nil, err
 	}
 	return obj, nil
@@ -227,29 +427,31 @@ func (b *Binder) updateSpecContainers(
 // returned object.
 func (b *Binder) removeSpecContainers(
 	obj *unstructured.Unstructured,
+	injected *injections,
 ) (*unstructured.Unstructured, error) {
-	containers, err := b.extractSpecContainers(obj)
+	adapter := injected.adapter(obj)
+	containers, err := b.extractSpecContainers(obj, adapter)
 	if err != nil {
 		return nil, err
 	}
-	if containers, err = b.removeContainers(containers); err != nil {
+	if containers, err = b.removeContainers(containers, injected); err != nil {
 		return nil, err
 	}
-	if err = unstructured.SetNestedSlice(obj.Object, containers, containersPath...); err != nil {
+	if err = unstructured.SetNestedSlice(obj.Object, containers, adapter.ContainersPath()...); err != nil {
 		return nil, err
 	}
 	return obj, nil
 }
 
 // updateContainers execute the update command per container found.
-func (b *Binder) updateContainers(containers []interface{}) ([]interface{}, error) {
+func (b *Binder) updateContainers(containers []interface{}, injected *injections) ([]interface{}, error) {
 	var err error
 
 	for i, container := range containers {
 		log := b.logger.WithValues("Obj.Container.Number", i)
 		log.Debug("Inspecting container...")
 
-		containers[i], err = b.updateContainer(container)
+		containers[i], err = b.updateContainer(container, injected.container(containerKey(i, container)))
 		if err != nil {
 			log.Error(err, "during container update to add binding items.")
 			return nil, err
@@ -259,15 +461,20 @@ func (b *Binder) updateContainers(containers []interface{}) ([]interface{}, erro
 	return containers, nil
 }
 
-// removeContainers execute removal of binding related entries in containers.
-func (b *Binder) removeContainers(containers []interface{}) ([]interface{}, error) {
+// removeContainers execute removal of injected entries in containers.
+func (b *Binder) removeContainers(containers []interface{}, injected *injections) ([]interface{}, error) {
 	var err error
 
 	for i, container := range containers {
 		log := b.logger.WithValues("Obj.Container.Number", i)
+		containerInjected, ok := injected.Containers[containerKey(i, container)]
+		if !ok {
+			log.Debug("Container has no injected items, skipping it...")
+			continue
+		}
 		log.Debug("Inspecting container...")
 
-		containers[i], err = b.removeContainer(container)
+		containers[i], err = b.removeContainer(container, containerInjected)
 		if err != nil {
 			log.Error(err, "during container update to remove binding items.")
 			return nil, err
@@ -302,11 +509,44 @@ func (b *Binder) appendEnvVar(
 	return updatedEnvList
 }
 
+// setEnvVar sets the informed environment variable, replacing the one having the same name.
+func (b *Binder) setEnvVar(envList []corev1.EnvVar, envVar corev1.EnvVar) []corev1.EnvVar {
+	for i, env := range envList {
+		if env.Name == envVar.Name {
+			envList[i] = envVar
+			return envList
+		}
+	}
+	return append(envList, envVar)
+}
+
+// updateSecretKeyEnvVars sets the environment variables referring to intermediary secret keys,
+// removing the ones injected before and not declared anymore.
+func (b *Binder) updateSecretKeyEnvVars(c *corev1.Container, injected *containerInjections) {
+	declared := []string{}
+	for _, env := range b.secretKeyEnv {
+		injected.Env = appendInjected(injected.Env, env.Name, hasEnvVar(c.Env, env.Name))
+		c.Env = b.setEnvVar(c.Env, env)
+		declared = append(declared, env.Name)
+	}
+
+	stale := []string{}
+	for _, name := range injected.Env {
+		if name != ServiceBindingRootEnv && name != ChangeTriggerEnv && !containsStringSlice(declared, name) {
+			stale = append(stale, name)
+		}
+	}
+	c.Env = b.removeEnvVars(c.Env, stale)
+	for _, name := range stale {
+		injected.Env = removeStringSlice(injected.Env, name)
+	}
+}
+
 // appendEnvFrom based on secret name and list of EnvFromSource instances, making sure secret is
 // part of the list or appended.
 func (b *Binder) appendEnvFrom(envList []corev1.EnvFromSource, secret string) []corev1.EnvFromSource {
 	for _, env := range envList {
-		if env.SecretRef.Name == secret {
+		if env.SecretRef != nil && env.SecretRef.Name == secret {
 			b.logger.Debug("Directive 'envFrom' is already present!")
 			// secret name is already referenced
 			return envList
@@ -327,7 +567,18 @@ func (b *Binder) appendEnvFrom(envList []corev1.EnvFromSource, secret string) []
 func (b *Binder) removeEnvFrom(envList []corev1.EnvFromSource, secret string) []corev1.EnvFromSource {
 	var cleanEnvList []corev1.EnvFromSource
 	for _, env := range envList {
-		if env.SecretRef.Name != secret {
+		if env.SecretRef == nil || env.SecretRef.Name != secret {
+			cleanEnvList = append(cleanEnvList, env)
+		}
+	}
+	return cleanEnvList
+}
+
+// removeEnvVars remove the environment variables having the informed names.
+func (b *Binder) removeEnvVars(envList []corev1.EnvVar, names []string) []corev1.EnvVar {
+	var cleanEnvList []corev1.EnvVar
+	for _, env := range envList {
+		if !containsStringSlice(names, env.Name) {
 			cleanEnvList = append(cleanEnvList, env)
 		}
 	}
@@ -346,57 +597,108 @@ func (b *Binder) containerFromUnstructured(container interface{}) (*corev1.Conta
 	return c, nil
 }
 
-// updateContainer execute the update of a single container, adding binding items.
-func (b *Binder) updateContainer(container interface{}) (map[string]interface{}, error) {
+// updateContainer execute the update of a single container, adding binding items and recording the
+// ones not present before.
+func (b *Binder) updateContainer(
+	container interface{},
+	injected *containerInjections,
+) (map[string]interface{}, error) {
 	c, err := b.containerFromUnstructured(container)
 	if err != nil {
 		return nil, err
 	}
+	name := b.sbr.GetName()
 
-	// effectively binding the application with intermediary secret
-	c.EnvFrom = b.appendEnvFrom(c.EnvFrom, b.sbr.GetName())
+	if b.sbr.Spec.BindAsFiles {
+		// effectively binding the application with intermediary secret, projected as files under
+		// the service binding root, which is declared to the application
+		root := b.serviceBindingRoot(c.Env)
+		injected.Env = appendInjected(
+			injected.Env, ServiceBindingRootEnv, hasEnvVar(c.Env, ServiceBindingRootEnv))
+		c.Env = b.appendEnvVar(c.Env, ServiceBindingRootEnv, root)
+		if containsStringSlice(injected.EnvFrom, name) {
+			// removing the envFrom directive injected when binding as environment variables
+			c.EnvFrom = b.removeEnvFrom(c.EnvFrom, name)
+			injected.EnvFrom = removeStringSlice(injected.EnvFrom, name)
+		}
+	} else {
+		// effectively binding the application with intermediary secret
+		injected.EnvFrom = appendInjected(injected.EnvFrom, name, hasEnvFromSecret(c.EnvFrom, name))
+		c.EnvFrom = b.appendEnvFrom(c.EnvFrom, name)
+		if containsStringSlice(injected.Env, ServiceBindingRootEnv) {
+			// removing the service binding root injected when binding as files
+			c.Env = b.removeEnvVars(c.Env, []string{ServiceBindingRootEnv})
+			injected.Env = removeStringSlice(injected.Env, ServiceBindingRootEnv)
+		}
+	}
 
-	// add a special environment variable that is only used to trigger a change in the declaration,
-	// attempting to force a side effect (in case of a Deployment, it would result in its Pods to be
-	// restarted)
-	c.Env = b.appendEnvVar(c.Env, ChangeTriggerEnv, time.Now().Format(time.RFC3339))
+	b.updateSecretKeyEnvVars(c, injected)
+
+	if b.restartStrategy() == v1alpha1.RestartStrategyEnvVar {
+		// add a special environment variable that is only used to trigger a change in the
+		// declaration when binding data changes, attempting to force a side effect (in case of a
+		// Deployment, it would result in its Pods to be restarted)
+		injected.Env = appendInjected(injected.Env, ChangeTriggerEnv, hasEnvVar(c.Env, ChangeTriggerEnv))
+		c.Env = b.appendEnvVar(c.Env, ChangeTriggerEnv, b.dataHash)
+	} else if containsStringSlice(injected.Env, ChangeTriggerEnv) {
+		// removing the environment variable injected by a former restart strategy
+		c.Env = b.removeEnvVars(c.Env, []string{ChangeTriggerEnv})
+		injected.Env = removeStringSlice(injected.Env, ChangeTriggerEnv)
+	}
 
-	if len(b.volumeKeys) > 0 {
-		// and adding volume mount entries
-		c.VolumeMounts = b.appendVolumeMounts(c.VolumeMounts)
+	if b.needsVolume() {
+		mountPath := b.volumeMountPath()
+		if b.sbr.Spec.BindAsFiles {
+			mountPath = b.bindingFilesPath(c.Env)
+		}
+		// and adding volume mount entries, leaving a volume mount with the same name declared by
+		// the user untouched
+		if !hasVolumeMount(c.VolumeMounts, name) || containsStringSlice(injected.VolumeMounts, name) {
+			injected.VolumeMounts = appendInjected(injected.VolumeMounts, name, false)
+			c.VolumeMounts = b.appendVolumeMounts(c.VolumeMounts, mountPath)
+		} else {
+			b.logger.Debug("Volume mount is already defined!", "Container.Name", c.Name)
+		}
+	} else if containsStringSlice(injected.VolumeMounts, name) {
+		// removing the volume mount injected for a former binding mode
+		c.VolumeMounts = b.removeVolumeMounts(c.VolumeMounts, []string{name})
+		injected.VolumeMounts = removeStringSlice(injected.VolumeMounts, name)
 	}
 
 	return runtime.DefaultUnstructuredConverter.ToUnstructured(c)
 }
 
-// removeContainer execute the update of single container to remove binding items.
-func (b *Binder) removeContainer(container interface{}) (map[string]interface{}, error) {
+// removeContainer execute the update of single container to remove the injected items.
+func (b *Binder) removeContainer(
+	container interface{},
+	injected *containerInjections,
+) (map[string]interface{}, error) {
 	c, err := b.containerFromUnstructured(container)
 	if err != nil {
 		return nil, err
 	}
 
 	// removing intermediary secret, effectively unbinding the application
-	c.EnvFrom = b.removeEnvFrom(c.EnvFrom, b.sbr.GetName())
-
-	if len(b.volumeKeys) > 0 {
-		// removing volume mount entries
-		c.VolumeMounts = b.removeVolumeMounts(c.VolumeMounts)
+	for _, secret := range injected.EnvFrom {
+		c.EnvFrom = b.removeEnvFrom(c.EnvFrom, secret)
 	}
+	c.Env = b.removeEnvVars(c.Env, injected.Env)
+	c.VolumeMounts = b.removeVolumeMounts(c.VolumeMounts, injected.VolumeMounts)
 
 	return runtime.DefaultUnstructuredConverter.ToUnstructured(c)
 }
 
-// appendVolumeMounts append the binding volume in the template level.
-func (b *Binder) appendVolumeMounts(volumeMounts []corev1.VolumeMount) []corev1.VolumeMount {
+// appendVolumeMounts append the binding volume in the template level, mounted on the informed path,
+// updating the mount path of the one injected before.
+func (b *Binder) appendVolumeMounts(
+	volumeMounts []corev1.VolumeMount,
+	mountPath string,
+) []corev1.VolumeMount {
 	name := b.sbr.GetName()
-	mountPath := b.sbr.Spec.MountPathPrefix
-	if mountPath == "" {
-		mountPath = "/var/data"
-	}
 
-	for _, v := range volumeMounts {
+	for i, v := range volumeMounts {
 		if name == v.Name {
+			volumeMounts[i].MountPath = mountPath
 			return volumeMounts
 		}
 	}
@@ -407,13 +709,15 @@ func (b *Binder) appendVolumeMounts(volumeMounts []corev1.VolumeMount) []corev1.
 	})
 }
 
-// removeVolumeMounts from informed slice of corev1.VolumeMount, make sure all binding related
-// entries won't be part of returned slice.
-func (b *Binder) removeVolumeMounts(volumeMounts []corev1.VolumeMount) []corev1.VolumeMount {
+// removeVolumeMounts from informed slice of corev1.VolumeMount, make sure entries having the
+// informed names won't be part of returned slice.
+func (b *Binder) removeVolumeMounts(
+	volumeMounts []corev1.VolumeMount,
+	names []string,
+) []corev1.VolumeMount {
 	var cleanVolumeMounts []corev1.VolumeMount
-	name := b.sbr.GetName()
 	for _, v := range volumeMounts {
-		if name != v.Name {
+		if !containsStringSlice(names, v.Name) {
 			cleanVolumeMounts = append(cleanVolumeMounts, v)
 		}
 	}
@@ -447,34 +751,124 @@ func nestedMapComparison(a, b *unstructured.Unstructured, fields ...string) (boo
 	return result.Success(), nil
 }
 
+// recordLegacyChangeTrigger records the change trigger environment variable found in the containers
+// of the informed object as injected, since it's owned by the operator, even when written by
+// operator versions not recording injections; it's then removed once not needed anymore.
+func (b *Binder) recordLegacyChangeTrigger(obj *unstructured.Unstructured, injected *injections) error {
+	containers, err := b.extractSpecContainers(obj, workloadAdapterFor(obj, b.bindingPath(obj)))
+	if err != nil {
+		return err
+	}
+	for idx, container := range containers {
+		c, err := b.containerFromUnstructured(container)
+		if err != nil {
+			return err
+		}
+		if hasEnvVar(c.Env, ChangeTriggerEnv) {
+			ci := injected.container(containerKey(idx, container))
+			ci.Env = appendInjected(ci.Env, ChangeTriggerEnv, false)
+		}
+	}
+	return nil
+}
+
 // update the list of objects informed as unstructured, looking for "containers" entry. This method
 // loops over each container to inspect "envFrom" and append the intermediary secret, having the same
 // name than original ServiceBindingRequest.
 func (b *Binder) update(objs *unstructured.UnstructuredList) ([]*unstructured.Unstructured, error) {
 	updatedObjs := []*unstructured.Unstructured{}
+	replicated := map[string]bool{}
+	b.drifts = nil
+	b.skipped = nil
 
-	for _, obj := range objs.Items {
+	for i := range objs.Items {
+		// referring to the list item, since returned objects must not share the same instance
+		obj := &objs.Items[i]
 		// store a copy of the original object to later be used in a comparison
 		originalObj := obj.DeepCopy()
 		name := obj.GetName()
 		log := b.logger.WithValues("Obj.Name", name, "Obj.Kind", obj.GetKind())
 		log.Debug("Inspecting object...")
 
-		updatedObj, err := b.updateSpecContainers(&obj)
+		// the API server rejects changes to the pod spec of immutable workloads, so those are
+		// skipped while the other workloads are bound
+		if immutableWorkloads[obj.GroupVersionKind().GroupKind()] {
+			log.Info("Skipping immutable workload")
+			b.skipped = append(b.skipped, fmt.Sprintf(
+				"%s '%s' in namespace '%s'", obj.GetKind(), name, obj.GetNamespace()))
+			continue
+		}
+
+		// objects in other namespaces refer to a replica of the intermediary secret, which must
+		// exist before they are updated
+		if ns := obj.GetNamespace(); ns != b.sbr.GetNamespace() && !replicated[ns] {
+			if err := b.replicateSecret(ns); err != nil {
+				return nil, err
+			}
+			replicated[ns] = true
+		}
+
+		// items injected by previous bindings are kept in the record
+		injected, found, err := readInjections(obj, b.namespacedName())
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			if err = b.recordLegacyChangeTrigger(obj, injected); err != nil {
+				return nil, err
+			}
+		}
+
+		// objects previously bound must still contain the binding items, otherwise those have been
+		// removed by someone else, and are re-applied
+		missing, err := b.missingInjections(obj, injected, found)
+		if err != nil {
+			return nil, err
+		}
+		if len(missing) > 0 {
+			log.Info("Binding has drifted, re-applying missing items", "Missing", missing)
+			b.drifts = append(b.drifts, fmt.Sprintf(
+				"%s '%s' is missing %s", obj.GetKind(), name, strings.Join(missing, ", ")))
+		}
+
+		// items are located by the binding path currently declared
+		injected.BindingPath = b.bindingPath(obj).DeepCopy()
+
+		updatedObj, err := b.updateSpecContainers(obj, injected)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(b.volumeKeys) > 0 {
-			if updatedObj, err = b.updateSpecVolumes(&obj); err != nil {
+		if b.needsVolume() {
+			if updatedObj, err = b.updateSpecVolumes(obj, injected); err != nil {
 				return nil, err
 			}
+		} else if containsStringSlice(injected.Volumes, b.sbr.GetName()) {
+			// the binding volume injected for a former binding mode is not needed anymore
+			if updatedObj, err = b.removeSpecVolumes(obj, injected); err != nil {
+				return nil, err
+			}
+			injected.Volumes = removeStringSlice(injected.Volumes, b.sbr.GetName())
+		}
+
+		if updatedObj, err = b.updateSpecPodAnnotations(updatedObj, injected); err != nil {
+			return nil, err
+		}
+
+		if err = writeInjections(updatedObj, b.namespacedName(), injected); err != nil {
+			return nil, err
 		}
 
 		if specsAreEqual, err := nestedMapComparison(originalObj, updatedObj, "spec"); err != nil {
 			log.Error(err, "")
 			continue
-		} else if specsAreEqual {
+		} else if annotationsAreEqual, err := nestedMapComparison(
+			originalObj, updatedObj, "metadata", "annotations"); err != nil {
+			log.Error(err, "")
+			continue
+		} else if specsAreEqual && annotationsAreEqual {
+			// object is already bound, still it must be part of the returned objects
+			updatedObjs = append(updatedObjs, originalObj)
 			continue
 		}
 
@@ -496,25 +890,49 @@ func (b *Binder) update(objs *unstructured.UnstructuredList) ([]*unstructured.Un
 		updatedObjs = append(updatedObjs, updatedObj)
 	}
 
+	if len(updatedObjs) == 0 && len(b.skipped) > 0 {
+		return nil, fmt.Errorf("%w: %s", ImmutableWorkloadErr, strings.Join(b.skipped, ", "))
+	}
 	return updatedObjs, nil
 }
 
-// remove attempts to update each given object without any service binding related information.
+// remove attempts to update each given object without the items recorded as injected by the
+// service binding request, leaving any other item untouched.
 func (b *Binder) remove(objs *unstructured.UnstructuredList) error {
-	for _, obj := range objs.Items {
+	for i := range objs.Items {
+		obj := &objs.Items[i]
 		name := obj.GetName()
 		logger := b.logger.WithValues("Obj.Name", name, "Obj.Kind", obj.GetKind())
 		logger.Debug("Inspecting object...")
 
-		updatedObj, err := b.removeSpecContainers(&obj)
+		injected, found, err := readInjections(obj, b.namespacedName())
 		if err != nil {
 			return err
 		}
-
-		if len(b.volumeKeys) > 0 {
-			if updatedObj, err = b.removeSpecVolumes(&obj); err != nil {
+		if !found {
+			logger.Debug("Injected items are not recorded, assuming the items named after the SBR")
+			containers, err := b.extractSpecContainers(obj, injected.adapter(obj))
+			if err != nil {
 				return err
 			}
+			injected = legacyInjections(b.sbr.GetName(), containers)
+		}
+
+		updatedObj, err := b.removeSpecContainers(obj, injected)
+		if err != nil {
+			return err
+		}
+
+		if updatedObj, err = b.removeSpecVolumes(updatedObj, injected); err != nil {
+			return err
+		}
+
+		if updatedObj, err = b.removeSpecPodAnnotations(updatedObj, injected); err != nil {
+			return err
+		}
+
+		if err = writeInjections(updatedObj, b.namespacedName(), nil); err != nil {
+			return err
 		}
 
 		logger.Debug("Updating object...")
@@ -525,23 +943,83 @@ func (b *Binder) remove(objs *unstructured.UnstructuredList) error {
 	return nil
 }
 
-// Unbind select objects subject to binding, and proceed with "remove", which will unbind objects.
+// Unbind select objects recorded as bound in the SBR status, and proceed with "remove", which will
+// unbind objects.
 func (b *Binder) Unbind() error {
-	objs, err := b.search()
+	objs, err := b.searchBound()
 	if err != nil {
 		return err
 	}
-	return b.remove(objs)
+	if err = b.remove(objs); err != nil {
+		return err
+	}
+	return b.removeSecretReplicas(nil)
+}
+
+// removeUnselected unbinds the objects recorded as bound in the SBR status that are not part of
+// the informed selected objects anymore, for instance when their labels have changed.
+func (b *Binder) removeUnselected(selected *unstructured.UnstructuredList) error {
+	bound, err := b.searchBound()
+	if err != nil {
+		return err
+	}
+
+	isSelected := func(obj *unstructured.Unstructured) bool {
+		if selected == nil {
+			return false
+		}
+		for _, s := range selected.Items {
+			if s.GroupVersionKind().GroupKind() == obj.GroupVersionKind().GroupKind() &&
+				s.GetNamespace() == obj.GetNamespace() && s.GetName() == obj.GetName() {
+				return true
+			}
+		}
+		return false
+	}
+
+	unselected := &unstructured.UnstructuredList{}
+	for _, obj := range bound.Items {
+		if !isSelected(&obj) {
+			unselected.Items = append(unselected.Items, obj)
+		}
+	}
+	return b.remove(unselected)
 }
 
 // Bind resources to intermediary secret, by searching informed ResourceKind containing the labels
-// in ApplicationSelector, and then updating spec.
+// in each application selector, and then updating spec. Objects previously bound and not selected
+// anymore are unbound, as well as the ones in namespaces not allowing bindings anymore, and
+// intermediary secret replicas not referred to anymore are deleted.
 func (b *Binder) Bind() ([]*unstructured.Unstructured, error) {
-	objs, err := b.search()
+	objs, searchErr := b.searchAll()
+	if searchErr != nil && !k8serror.IsNotFound(searchErr) &&
+		!errors.Is(searchErr, ApplicationNamespaceNotAllowedErr) {
+		return nil, searchErr
+	}
+	if removeErr := b.removeUnselected(objs); removeErr != nil {
+		return nil, removeErr
+	}
+	if objs == nil {
+		// no objects are bound anymore
+		if removeErr := b.removeSecretReplicas(nil); removeErr != nil {
+			return nil, removeErr
+		}
+		return []*unstructured.Unstructured{}, searchErr
+	}
+
+	updatedObjs, err := b.update(objs)
 	if err != nil {
 		return nil, err
 	}
-	return b.update(objs)
+	namespaces := map[string]bool{}
+	for _, obj := range updatedObjs {
+		namespaces[obj.GetNamespace()] = true
+	}
+	if err = b.removeSecretReplicas(namespaces); err != nil {
+		return nil, err
+	}
+	// objects of the other selectors are bound, still a namespace not allowing bindings is reported
+	return updatedObjs, searchErr
 }
 
 // NewBinder returns a new Binder instance.
@@ -549,15 +1027,18 @@ func NewBinder(
 	ctx context.Context,
 	client client.Client,
 	dynClient dynamic.Interface,
+	restMapper meta.RESTMapper,
 	sbr *v1alpha1.ServiceBindingRequest,
-	volumeKeys []string,
+	volumeKeys []VolumeKey,
 ) *Binder {
 	return &Binder{
-		ctx:        ctx,
-		client:     client,
-		dynClient:  dynClient,
-		sbr:        sbr,
-		volumeKeys: volumeKeys,
-		logger:     log.NewLog("binder"),
+		ctx:          ctx,
+		client:       client,
+		dynClient:    dynClient,
+		restMapper:   restMapper,
+		sbr:          sbr,
+		volumeKeys:   volumeKeys,
+		bindingPaths: make(map[objectReference]*v1alpha1.BindingPath),
+		logger:       log.NewLog("binder"),
 	}
 }
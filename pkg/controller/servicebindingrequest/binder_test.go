@@ -2,17 +2,22 @@ package servicebindingrequest
 
 import (
 	"context"
+	"errors"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/test/mocks"
 )
 
@@ -45,8 +50,9 @@ func TestBinderNew(t *testing.T) {
 		context.TODO(),
 		f.FakeClient(),
 		f.FakeDynClient(),
+		f.FakeRESTMapper(),
 		sbr,
-		[]string{},
+		[]VolumeKey{},
 	)
 
 	require.NotNil(t, binder)
@@ -64,20 +70,21 @@ func TestBinderNew(t *testing.T) {
 		context.TODO(),
 		f.FakeClient(),
 		f.FakeDynClient(),
+		f.FakeRESTMapper(),
 		sbrWithResourceRef,
-		[]string{},
+		[]VolumeKey{},
 	)
 
 	require.NotNil(t, binderForSBRWithResourceRef)
 
 	t.Run("search-using-resourceref", func(t *testing.T) {
-		list, err := binderForSBRWithResourceRef.search()
+		list, err := binderForSBRWithResourceRef.search(binderForSBRWithResourceRef.sbr.Spec.ApplicationSelector)
 		require.NoError(t, err)
 		require.Equal(t, 1, len(list.Items))
 	})
 
 	t.Run("search", func(t *testing.T) {
-		list, err := binder.search()
+		list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
 		require.NoError(t, err)
 		require.Equal(t, 1, len(list.Items))
 	})
@@ -104,7 +111,7 @@ func TestBinderNew(t *testing.T) {
 	})
 
 	t.Run("update", func(t *testing.T) {
-		list, err := binder.search()
+		list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
 		require.NoError(t, err)
 		require.Equal(t, 1, len(list.Items))
 
@@ -122,19 +129,17 @@ func TestBinderNew(t *testing.T) {
 		err = runtime.DefaultUnstructuredConverter.FromUnstructured(u, &c)
 		require.NoError(t, err)
 
-		// special env-var should exist to trigger a side effect such as Pod restart when the
-		// intermediate secret has been modified
-		envVar := getEnvVar(c.Env, ChangeTriggerEnv)
-		require.NotNil(t, envVar)
-		require.NotEmpty(t, envVar.Value)
-
-		parsedTime, err := time.Parse(time.RFC3339, envVar.Value)
+		// by default, a pod template annotation holds the data hash to trigger a side effect such
+		// as Pod restart when the intermediate secret has been modified
+		require.Nil(t, getEnvVar(c.Env, ChangeTriggerEnv))
+		annotations, found, err := unstructured.NestedStringMap(list.Items[0].Object, podAnnotationsPath...)
 		require.NoError(t, err)
-		require.True(t, parsedTime.Before(time.Now()))
+		require.True(t, found)
+		require.Contains(t, annotations, bindingHashAnnotation)
 	})
 
 	t.Run("remove", func(t *testing.T) {
-		list, err := binder.search()
+		list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
 		require.NoError(t, err)
 		require.Equal(t, 1, len(list.Items))
 
@@ -162,6 +167,87 @@ func TestBinderNew(t *testing.T) {
 	})
 }
 
+func TestBinderSearchWithMatchExpressions(t *testing.T) {
+	ns := "binder"
+	f := mocks.NewFake(t, ns)
+	f.AddMockedUnstructuredDeployment("frontend", map[string]string{"connects-to": "database", "tier": "frontend"})
+	f.AddMockedUnstructuredDeployment("backend", map[string]string{"connects-to": "database", "tier": "backend"})
+	f.AddMockedUnstructuredDeployment("worker", map[string]string{"tier": "worker"})
+
+	// buildBinder creates a binder for a SBR using the informed label selector.
+	buildBinder := func(name string, labelSelector *metav1.LabelSelector) *Binder {
+		sbr := f.AddMockedServiceBindingRequest(name, nil, "ref", "", deploymentsGVR, nil)
+		sbr.Spec.ApplicationSelector.LabelSelector = labelSelector
+		return NewBinder(context.TODO(), f.FakeClient(), f.FakeDynClient(), f.FakeRESTMapper(), sbr, []VolumeKey{})
+	}
+
+	// names returns the names of the informed objects.
+	names := func(list *unstructured.UnstructuredList) []string {
+		result := []string{}
+		for _, obj := range list.Items {
+			result = append(result, obj.GetName())
+		}
+		return result
+	}
+
+	t.Run("match labels and expressions", func(t *testing.T) {
+		binder := buildBinder("match-labels-and-expressions", &metav1.LabelSelector{
+			MatchLabels: map[string]string{"connects-to": "database"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"backend"}},
+			},
+		})
+		list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"frontend"}, names(list))
+	})
+
+	t.Run("in and exists expressions", func(t *testing.T) {
+		binder := buildBinder("in-and-exists", &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"backend", "worker"}},
+				{Key: "connects-to", Operator: metav1.LabelSelectorOpExists},
+			},
+		})
+		list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"backend"}, names(list))
+	})
+
+	t.Run("does not exist expression", func(t *testing.T) {
+		binder := buildBinder("does-not-exist", &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "connects-to", Operator: metav1.LabelSelectorOpDoesNotExist},
+			},
+		})
+		list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"worker"}, names(list))
+	})
+
+	t.Run("unsupported operator", func(t *testing.T) {
+		binder := buildBinder("unsupported-operator", &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: "Unknown", Values: []string{"backend"}},
+			},
+		})
+		_, err := binder.search(binder.sbr.Spec.ApplicationSelector)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, InvalidApplicationLabelSelectorErr))
+	})
+
+	t.Run("invalid expression values", func(t *testing.T) {
+		binder := buildBinder("invalid-values", &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: metav1.LabelSelectorOpIn},
+			},
+		})
+		_, err := binder.search(binder.sbr.Spec.ApplicationSelector)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, InvalidApplicationLabelSelectorErr))
+	})
+}
+
 func TestBinderAppendEnvVar(t *testing.T) {
 	envName := "lastbound"
 	envList := []corev1.EnvVar{
@@ -192,14 +278,15 @@ func TestBinderApplicationName(t *testing.T) {
 		context.TODO(),
 		f.FakeClient(),
 		f.FakeDynClient(),
+		f.FakeRESTMapper(),
 		sbr,
-		[]string{},
+		[]VolumeKey{},
 	)
 
 	require.NotNil(t, binder)
 
 	t.Run("search by application name", func(t *testing.T) {
-		list, err := binder.search()
+		list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
 		require.NoError(t, err)
 		require.Equal(t, 1, len(list.Items))
 	})
@@ -216,14 +303,15 @@ func TestBindingWithDeploymentConfig(t *testing.T) {
 		context.TODO(),
 		f.FakeClient(),
 		f.FakeDynClient(),
+		f.FakeRESTMapper(),
 		sbr,
-		[]string{},
+		[]VolumeKey{},
 	)
 
 	require.NotNil(t, binder)
 
 	t.Run("deploymentconfig", func(t *testing.T) {
-		list, err := binder.search()
+		list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
 		require.NoError(t, err)
 		require.Equal(t, 1, len(list.Items))
 		require.Equal(t, "DeploymentConfig", list.Items[0].Object["kind"])
@@ -246,8 +334,9 @@ func TestBindTwoApplications(t *testing.T) {
 		context.TODO(),
 		f.FakeClient(),
 		f.FakeDynClient(),
+		f.FakeRESTMapper(),
 		sbr1,
-		[]string{},
+		[]VolumeKey{},
 	)
 	require.NotNil(t, binder1)
 
@@ -262,22 +351,143 @@ func TestBindTwoApplications(t *testing.T) {
 		context.TODO(),
 		f.FakeClient(),
 		f.FakeDynClient(),
+		f.FakeRESTMapper(),
 		sbr2,
-		[]string{},
+		[]VolumeKey{},
 	)
 	require.NotNil(t, binder2)
 
 	t.Run("two applications with one backing service", func(t *testing.T) {
-		list1, err := binder1.search()
+		list1, err := binder1.search(binder1.sbr.Spec.ApplicationSelector)
 		assert.Nil(t, err)
 		assert.Equal(t, 1, len(list1.Items))
 
-		list2, err := binder2.search()
+		list2, err := binder2.search(binder2.sbr.Spec.ApplicationSelector)
 		assert.Nil(t, err)
 		assert.Equal(t, 1, len(list2.Items))
 	})
 }
 
+func TestBinderBindUnselected(t *testing.T) {
+	ns := "binder"
+	name := "service-binding-request"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "binder",
+	}
+
+	f := mocks.NewFake(t, ns)
+	f.AddMockedUnstructuredDeployment("app", matchLabels)
+	f.AddMockedUnstructuredDeployment("other", matchLabels)
+	sbr := mocks.ServiceBindingRequestMock(ns, name, nil, "ref", "", deploymentsGVR, matchLabels)
+
+	fakeClient := f.FakeClient()
+	fakeDynClient := f.FakeDynClient()
+	binder := NewBinder(context.TODO(), fakeClient, fakeDynClient, f.FakeRESTMapper(), sbr, []VolumeKey{})
+
+	updatedObjects, err := binder.Bind()
+	require.NoError(t, err)
+	require.Len(t, updatedObjects, 2)
+	for _, obj := range updatedObjects {
+		sbr.Status.ApplicationObjects = append(sbr.Status.ApplicationObjects, v1alpha1.BoundApplication{
+			GroupVersionKind:     metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			LocalObjectReference: corev1.LocalObjectReference{Name: obj.GetName()},
+		})
+	}
+
+	// unlabel applications on the cluster, keeping the state recorded when binding
+	unlabel := func(t *testing.T, obj *unstructured.Unstructured) {
+		u := obj.DeepCopy()
+		u.SetLabels(nil)
+		_, err := fakeDynClient.Resource(deploymentsGVR).Namespace(ns).Update(u, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+
+	// isBound returns whether the application named after the informed name is still bound
+	isBound := func(t *testing.T, name string) bool {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: name}, u))
+		_, found := u.GetAnnotations()[injectionsAnnotation]
+		return found
+	}
+
+	t.Run("one application unlabeled", func(t *testing.T) {
+		unlabel(t, updatedObjects[0])
+
+		objs, err := binder.Bind()
+		require.NoError(t, err)
+		require.Len(t, objs, 1)
+		require.Equal(t, updatedObjects[1].GetName(), objs[0].GetName())
+		require.False(t, isBound(t, updatedObjects[0].GetName()))
+		require.True(t, isBound(t, updatedObjects[1].GetName()))
+	})
+
+	t.Run("all applications unlabeled", func(t *testing.T) {
+		unlabel(t, updatedObjects[1])
+
+		objs, err := binder.Bind()
+		require.Error(t, err)
+		require.Empty(t, objs)
+		require.False(t, isBound(t, updatedObjects[1].GetName()))
+	})
+}
+
+func TestBinderBindApplicationSelectors(t *testing.T) {
+	ns := "binder"
+	name := "service-binding-request"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "binder",
+	}
+	cronJobsGVR := schema.GroupVersionResource{Group: "batch", Version: "v1beta1", Resource: "cronjobs"}
+
+	f := mocks.NewFake(t, ns)
+	f.AddMockedUnstructuredDeployment("app", matchLabels)
+	f.AddMockedUnstructuredDeployment("other", nil)
+	f.AddMockedUnstructuredCronJob("job", matchLabels)
+	sbr := mocks.ServiceBindingRequestMock(ns, name, nil, "ref", "", deploymentsGVR, matchLabels)
+	labelSelector := sbr.Spec.ApplicationSelector.LabelSelector
+	sbr.Spec.ApplicationSelectors = &[]v1alpha1.ApplicationSelector{{
+		GroupVersionResource: metav1.GroupVersionResource(cronJobsGVR),
+		LabelSelector:        labelSelector,
+	}, {
+		GroupVersionResource: metav1.GroupVersionResource(deploymentsGVR),
+		LabelSelector:        labelSelector,
+	}, {
+		GroupVersionResource: metav1.GroupVersionResource(deploymentsGVR),
+		LabelSelector:        &metav1.LabelSelector{MatchLabels: map[string]string{"connects-to": "other"}},
+	}}
+
+	binder := NewBinder(context.TODO(), f.FakeClient(), f.FakeDynClient(), f.FakeRESTMapper(), sbr, []VolumeKey{})
+
+	updatedObjects, err := binder.Bind()
+	require.NoError(t, err)
+	names := []string{}
+	for _, obj := range updatedObjects {
+		names = append(names, obj.GetKind()+"/"+obj.GetName())
+	}
+	require.ElementsMatch(t, []string{"Deployment/app", "CronJob/job"}, names)
+
+	// applications selected more than once are bound once, still being part of each group, while
+	// selectors not finding applications don't prevent the others from being bound
+	require.Len(t, binder.groups, 4)
+	groupNames := [][]string{}
+	for _, group := range binder.groups {
+		refNames := []string{}
+		for _, ref := range group.refs {
+			refNames = append(refNames, ref.Kind+"/"+ref.Name)
+		}
+		groupNames = append(groupNames, refNames)
+	}
+	require.Equal(t, [][]string{
+		{"Deployment/app"},
+		{"CronJob/job"},
+		{"Deployment/app"},
+		{},
+	}, groupNames)
+}
+
 func TestKnativeServicesContractWithBinder(t *testing.T) {
 	ns := "binder"
 	name := "service-binding-request"
@@ -295,17 +505,416 @@ func TestKnativeServicesContractWithBinder(t *testing.T) {
 		context.TODO(),
 		f.FakeClient(),
 		f.FakeDynClient(),
+		f.FakeRESTMapper(),
 		sbr,
-		[]string{},
+		[]VolumeKey{},
 	)
 
 	require.NotNil(t, binder)
 
 	t.Run("Knative service contract with service binding operator", func(t *testing.T) {
-		list, err := binder.search()
+		list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
 		assert.Nil(t, err)
 		assert.Equal(t, 1, len(list.Items))
 
 	})
 
 }
+
+// TestBinderInjections exercises recording injected items on bind, and reverting exactly those on
+// unbind, using each supported workload kind.
+func TestBinderInjections(t *testing.T) {
+	ns := "binder"
+	name := "service-binding-request"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "binder",
+	}
+
+	// addUserItems adds items owned by the user in the informed workload, which must be left
+	// untouched by binding and unbinding.
+	addUserItems := func(t *testing.T, obj *unstructured.Unstructured) {
+		adapter := workloadAdapterFor(obj, nil)
+		containers, found, err := unstructured.NestedSlice(obj.Object, adapter.ContainersPath()...)
+		require.NoError(t, err)
+		require.True(t, found)
+
+		c := &corev1.Container{}
+		require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(
+			containers[0].(map[string]interface{}), c))
+		c.Env = append(c.Env, corev1.EnvVar{Name: "USER_VAR", Value: "user"})
+		c.EnvFrom = append(c.EnvFrom, corev1.EnvFromSource{
+			ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "user-config"},
+			},
+		})
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{Name: "user-data", MountPath: "/data"})
+		containers[0], err = runtime.DefaultUnstructuredConverter.ToUnstructured(c)
+		require.NoError(t, err)
+		require.NoError(t, unstructured.SetNestedSlice(obj.Object, containers, adapter.ContainersPath()...))
+
+		volume, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&corev1.Volume{
+			Name:         "user-data",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+		require.NoError(t, err)
+		require.NoError(t, unstructured.SetNestedSlice(obj.Object, []interface{}{volume}, adapter.VolumesPath()...))
+	}
+
+	// podSpec extracts containers and volumes from the informed workload.
+	podSpec := func(t *testing.T, obj *unstructured.Unstructured) *corev1.PodSpec {
+		adapter := workloadAdapterFor(obj, nil)
+		containers, found, err := unstructured.NestedSlice(obj.Object, adapter.ContainersPath()...)
+		require.NoError(t, err)
+		require.True(t, found)
+		volumes, _, err := unstructured.NestedSlice(obj.Object, adapter.VolumesPath()...)
+		require.NoError(t, err)
+		spec := map[string]interface{}{"containers": containers, "volumes": volumes}
+		podSpec := &corev1.PodSpec{}
+		require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(spec, podSpec))
+		return podSpec
+	}
+
+	tests := []struct {
+		name        string
+		gvr         schema.GroupVersionResource
+		addWorkload func(f *mocks.Fake) *unstructured.Unstructured
+	}{
+		{
+			name: "deployment",
+			gvr:  deploymentsGVR,
+			addWorkload: func(f *mocks.Fake) *unstructured.Unstructured {
+				return f.AddMockedUnstructuredDeployment("app", matchLabels)
+			},
+		},
+		{
+			name: "deploymentconfig",
+			gvr:  deploymentConfigsGVR,
+			addWorkload: func(f *mocks.Fake) *unstructured.Unstructured {
+				return f.AddMockedUnstructuredDeploymentConfig("app", matchLabels)
+			},
+		},
+		{
+			name: "knative service",
+			gvr:  knativev1.SchemeGroupVersion.WithResource("services"),
+			addWorkload: func(f *mocks.Fake) *unstructured.Unstructured {
+				return f.AddMockedUnstructuredKnativeService("app", matchLabels)
+			},
+		},
+		{
+			name: "cronjob",
+			gvr:  schema.GroupVersionResource{Group: "batch", Version: "v1beta1", Resource: "cronjobs"},
+			addWorkload: func(f *mocks.Fake) *unstructured.Unstructured {
+				return f.AddMockedUnstructuredCronJob("app", matchLabels)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := mocks.NewFake(t, ns)
+			obj := tt.addWorkload(f)
+			addUserItems(t, obj)
+			original := podSpec(t, obj)
+			sbr := f.AddMockedServiceBindingRequest(name, nil, "ref", "", tt.gvr, matchLabels)
+
+			binder := NewBinder(
+				context.TODO(),
+				f.FakeClient(),
+				f.FakeDynClient(),
+				f.FakeRESTMapper(),
+				sbr,
+				[]VolumeKey{{Key: "DATABASE_SECRET_PASSWORD", Path: "DATABASE_SECRET_PASSWORD"}},
+			)
+
+			list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
+			require.NoError(t, err)
+			require.Len(t, list.Items, 1)
+
+			updatedObjects, err := binder.update(list)
+			require.NoError(t, err)
+			require.Len(t, updatedObjects, 1)
+			bound := updatedObjects[0]
+
+			// all injected items are recorded, and nothing else
+			injected, found, err := readInjections(bound, binder.namespacedName())
+			require.NoError(t, err)
+			require.True(t, found)
+			require.Equal(t, []string{name}, injected.Volumes)
+			require.Len(t, injected.Containers, 1)
+			require.True(t, injected.BindingHash)
+			require.Equal(t, &containerInjections{
+				EnvFrom:      []string{name},
+				VolumeMounts: []string{name},
+			}, injected.Containers["busybox"])
+
+			boundSpec := podSpec(t, bound)
+			require.Len(t, boundSpec.Containers[0].Env, 1)
+			require.Len(t, boundSpec.Containers[0].EnvFrom, 2)
+			require.Len(t, boundSpec.Containers[0].VolumeMounts, 2)
+			require.Len(t, boundSpec.Volumes, 2)
+
+			// binding again must keep the record stable
+			list, err = binder.search(binder.sbr.Spec.ApplicationSelector)
+			require.NoError(t, err)
+			list.Items[0] = *bound.DeepCopy()
+			updatedObjects, err = binder.update(list)
+			require.NoError(t, err)
+			require.Len(t, updatedObjects, 1)
+			rebound, _, err := readInjections(updatedObjects[0], binder.namespacedName())
+			require.NoError(t, err)
+			require.Equal(t, injected, rebound)
+
+			// unbinding reverts the workload to its original state
+			err = binder.remove(&unstructured.UnstructuredList{
+				Items: []unstructured.Unstructured{*updatedObjects[0].DeepCopy()},
+			})
+			require.NoError(t, err)
+
+			unbound := &unstructured.Unstructured{}
+			unbound.SetGroupVersionKind(bound.GroupVersionKind())
+			namespacedName := types.NamespacedName{Namespace: ns, Name: bound.GetName()}
+			require.NoError(t, binder.client.Get(context.TODO(), namespacedName, unbound))
+			require.Equal(t, original, podSpec(t, unbound))
+			require.NotContains(t, unbound.GetAnnotations(), injectionsAnnotation)
+			_, found, err = unstructured.NestedFieldNoCopy(
+				unbound.Object, workloadAdapterFor(unbound, nil).PodAnnotationsPath()...)
+			require.NoError(t, err)
+			require.False(t, found)
+		})
+	}
+
+	t.Run("items present before binding are not recorded", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		obj := f.AddMockedUnstructuredDeployment("app", matchLabels)
+		containers, _, err := unstructured.NestedSlice(obj.Object, containersPath...)
+		require.NoError(t, err)
+		c := containers[0].(map[string]interface{})
+		c["envFrom"] = []interface{}{
+			map[string]interface{}{"secretRef": map[string]interface{}{"name": name}},
+		}
+		require.NoError(t, unstructured.SetNestedSlice(obj.Object, containers, containersPath...))
+		sbr := f.AddMockedServiceBindingRequest(name, nil, "ref", "", deploymentsGVR, matchLabels)
+
+		binder := NewBinder(context.TODO(), f.FakeClient(), f.FakeDynClient(), f.FakeRESTMapper(), sbr, []VolumeKey{})
+		list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
+		require.NoError(t, err)
+		updatedObjects, err := binder.update(list)
+		require.NoError(t, err)
+		require.Len(t, updatedObjects, 1)
+
+		injected, found, err := readInjections(updatedObjects[0], binder.namespacedName())
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Empty(t, injected.Containers["busybox"].EnvFrom)
+
+		err = binder.remove(&unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{*updatedObjects[0].DeepCopy()},
+		})
+		require.NoError(t, err)
+
+		d := appsv1.Deployment{}
+		namespacedName := types.NamespacedName{Namespace: ns, Name: "app"}
+		require.NoError(t, binder.client.Get(context.TODO(), namespacedName, &d))
+		require.Len(t, d.Spec.Template.Spec.Containers[0].EnvFrom, 1)
+		require.Empty(t, d.Spec.Template.Spec.Containers[0].Env)
+	})
+
+	t.Run("volume mounts declared by the user are left untouched", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		obj := f.AddMockedUnstructuredDeployment("app", matchLabels)
+		containers, _, err := unstructured.NestedSlice(obj.Object, containersPath...)
+		require.NoError(t, err)
+		c := containers[0].(map[string]interface{})
+		c["volumeMounts"] = []interface{}{
+			map[string]interface{}{"name": name, "mountPath": "/user/data"},
+		}
+		require.NoError(t, unstructured.SetNestedSlice(obj.Object, containers, containersPath...))
+		sbr := f.AddMockedServiceBindingRequest(name, nil, "ref", "", deploymentsGVR, matchLabels)
+
+		binder := NewBinder(
+			context.TODO(),
+			f.FakeClient(),
+			f.FakeDynClient(),
+			f.FakeRESTMapper(),
+			sbr,
+			[]VolumeKey{{Key: "DATABASE_SECRET_PASSWORD", Path: "DATABASE_SECRET_PASSWORD"}},
+		)
+		list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
+		require.NoError(t, err)
+		updatedObjects, err := binder.update(list)
+		require.NoError(t, err)
+		require.Len(t, updatedObjects, 1)
+
+		injected, found, err := readInjections(updatedObjects[0], binder.namespacedName())
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Empty(t, injected.Containers["busybox"].VolumeMounts)
+
+		d := appsv1.Deployment{}
+		namespacedName := types.NamespacedName{Namespace: ns, Name: "app"}
+		require.NoError(t, binder.client.Get(context.TODO(), namespacedName, &d))
+		expected := []corev1.VolumeMount{{Name: name, MountPath: "/user/data"}}
+		require.Equal(t, expected, d.Spec.Template.Spec.Containers[0].VolumeMounts)
+
+		err = binder.remove(&unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{*updatedObjects[0].DeepCopy()},
+		})
+		require.NoError(t, err)
+
+		d = appsv1.Deployment{}
+		require.NoError(t, binder.client.Get(context.TODO(), namespacedName, &d))
+		require.Equal(t, expected, d.Spec.Template.Spec.Containers[0].VolumeMounts)
+	})
+}
+
+// TestBinderRestartStrategy exercises how each restart strategy triggers a workload rollout, which
+// must only happen when the binding data changes.
+func TestBinderRestartStrategy(t *testing.T) {
+	ns := "binder"
+	name := "service-binding-request"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "binder",
+	}
+
+	// bind binds the deployment using the informed strategy and data, returning the bound object.
+	bind := func(
+		t *testing.T,
+		f *mocks.Fake,
+		obj *unstructured.Unstructured,
+		strategy v1alpha1.RestartStrategy,
+		data map[string][]byte,
+	) *unstructured.Unstructured {
+		sbr := mocks.ServiceBindingRequestMock(ns, name, nil, "ref", "", deploymentsGVR, matchLabels)
+		sbr.Spec.RestartStrategy = strategy
+		binder := NewBinder(context.TODO(), f.FakeClient(), f.FakeDynClient(), f.FakeRESTMapper(), sbr, []VolumeKey{})
+		binder.dataHash = hashData(data)
+
+		updatedObjects, err := binder.update(&unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{*obj.DeepCopy()},
+		})
+		require.NoError(t, err)
+		require.Len(t, updatedObjects, 1)
+		return updatedObjects[0]
+	}
+
+	// bindingHash returns the binding hash annotation found in the pod template.
+	bindingHash := func(t *testing.T, obj *unstructured.Unstructured) string {
+		annotations, _, err := unstructured.NestedStringMap(obj.Object, podAnnotationsPath...)
+		require.NoError(t, err)
+		return annotations[bindingHashAnnotation]
+	}
+
+	// triggerEnvVar returns the change trigger environment variable of the first container.
+	triggerEnvVar := func(t *testing.T, obj *unstructured.Unstructured) *corev1.EnvVar {
+		containers, _, err := unstructured.NestedSlice(obj.Object, containersPath...)
+		require.NoError(t, err)
+		c := corev1.Container{}
+		require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(
+			containers[0].(map[string]interface{}), &c))
+		return getEnvVar(c.Env, ChangeTriggerEnv)
+	}
+
+	data := map[string][]byte{"password": []byte("secret")}
+	changedData := map[string][]byte{"password": []byte("changed")}
+
+	t.Run("hash annotation", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		obj := f.AddMockedUnstructuredDeployment("app", matchLabels)
+
+		bound := bind(t, f, obj, "", data)
+		hash := bindingHash(t, bound)
+		require.Contains(t, hash, hashData(data))
+		require.Nil(t, triggerEnvVar(t, bound))
+
+		// binding the same data doesn't change the pod template
+		rebound := bind(t, f, bound, v1alpha1.RestartStrategyHashAnnotation, data)
+		require.Equal(t, bound.Object["spec"], rebound.Object["spec"])
+
+		// binding different data changes the pod template
+		rebound = bind(t, f, bound, v1alpha1.RestartStrategyHashAnnotation, changedData)
+		require.Contains(t, bindingHash(t, rebound), hashData(changedData))
+	})
+
+	t.Run("env var", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		obj := f.AddMockedUnstructuredDeployment("app", matchLabels)
+
+		bound := bind(t, f, obj, v1alpha1.RestartStrategyEnvVar, data)
+		envVar := triggerEnvVar(t, bound)
+		require.NotNil(t, envVar)
+		require.Equal(t, hashData(data), envVar.Value)
+		require.Empty(t, bindingHash(t, bound))
+
+		rebound := bind(t, f, bound, v1alpha1.RestartStrategyEnvVar, data)
+		require.Equal(t, bound.Object["spec"], rebound.Object["spec"])
+
+		// switching strategy removes the environment variable
+		rebound = bind(t, f, bound, v1alpha1.RestartStrategyHashAnnotation, data)
+		require.Nil(t, triggerEnvVar(t, rebound))
+		require.NotEmpty(t, bindingHash(t, rebound))
+		injected, _, err := readInjections(rebound, types.NamespacedName{Namespace: ns, Name: name})
+		require.NoError(t, err)
+		require.Empty(t, injected.Containers["busybox"].Env)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		obj := f.AddMockedUnstructuredDeployment("app", matchLabels)
+
+		bound := bind(t, f, obj, v1alpha1.RestartStrategyNone, data)
+		require.Nil(t, triggerEnvVar(t, bound))
+		require.Empty(t, bindingHash(t, bound))
+
+		rebound := bind(t, f, bound, v1alpha1.RestartStrategyNone, changedData)
+		require.Equal(t, bound.Object["spec"], rebound.Object["spec"])
+	})
+
+	// legacyBound returns a deployment bound by operator versions not recording injections, which
+	// always set the environment variable to trigger rollouts.
+	legacyBound := func(t *testing.T, f *mocks.Fake) *unstructured.Unstructured {
+		obj := f.AddMockedUnstructuredDeployment("app", matchLabels)
+		containers, _, err := unstructured.NestedSlice(obj.Object, containersPath...)
+		require.NoError(t, err)
+		c := containers[0].(map[string]interface{})
+		c["env"] = []interface{}{map[string]interface{}{"name": ChangeTriggerEnv, "value": "legacy"}}
+		c["envFrom"] = []interface{}{
+			map[string]interface{}{"secretRef": map[string]interface{}{"name": name}},
+		}
+		require.NoError(t, unstructured.SetNestedSlice(obj.Object, containers, containersPath...))
+		return obj
+	}
+
+	t.Run("upgrade to hash annotation", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		obj := legacyBound(t, f)
+		require.NotNil(t, triggerEnvVar(t, obj))
+
+		bound := bind(t, f, obj, v1alpha1.RestartStrategyHashAnnotation, data)
+		require.Nil(t, triggerEnvVar(t, bound))
+		require.NotEmpty(t, bindingHash(t, bound))
+	})
+
+	t.Run("upgrade keeping env var", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		obj := legacyBound(t, f)
+
+		bound := bind(t, f, obj, v1alpha1.RestartStrategyEnvVar, data)
+		require.Equal(t, hashData(data), triggerEnvVar(t, bound).Value)
+		injected, _, err := readInjections(bound, types.NamespacedName{Namespace: ns, Name: name})
+		require.NoError(t, err)
+		require.Equal(t, []string{ChangeTriggerEnv}, injected.Containers["busybox"].Env)
+
+		// unbinding removes the environment variable along with the other recorded items
+		sbr := mocks.ServiceBindingRequestMock(ns, name, nil, "ref", "", deploymentsGVR, matchLabels)
+		fakeClient := f.FakeClient()
+		binder := NewBinder(context.TODO(), fakeClient, f.FakeDynClient(), f.FakeRESTMapper(), sbr, []VolumeKey{})
+		require.NoError(t, binder.remove(&unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{*bound.DeepCopy()},
+		}))
+		d := appsv1.Deployment{}
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: "app"}, &d))
+		require.Nil(t, getEnvVar(d.Spec.Template.Spec.Containers[0].Env, ChangeTriggerEnv))
+	})
+}
@@ -3,15 +3,19 @@ package servicebindingrequest
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
 	"gotest.tools/assert/cmp"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -26,6 +30,25 @@ const (
 	BindingSuccess = "BindingSuccess"
 	// BindingFail binding has failed
 	BindingFail = "BindingFail"
+	// InvalidApplicationLabelSelector application label selector can't be used to search objects
+	InvalidApplicationLabelSelector = "InvalidApplicationLabelSelector"
+	// UnknownKind a kind related to the binding is not served by the cluster
+	UnknownKind = "UnknownKind"
+	// ReadAccessDenied user requesting the binding is not allowed to read a backing service
+	ReadAccessDenied = "ReadAccessDenied"
+	// ApplicationNamespaceNotAllowed application namespace doesn't allow bindings from the service
+	// binding request namespace
+	ApplicationNamespaceNotAllowed = "ApplicationNamespaceNotAllowed"
+	// BackingServiceNamespaceNotAllowed backing service namespace doesn't allow being consumed from
+	// the service binding request namespace
+	BackingServiceNamespaceNotAllowed = "BackingServiceNamespaceNotAllowed"
+	// ImmutableWorkload the selected workloads can't be changed once created
+	ImmutableWorkload = "ImmutableWorkload"
+	// SecretReplicaConflict a secret not replicated for the service binding request exists in an
+	// application namespace under the intermediary secret name
+	SecretReplicaConflict = "SecretReplicaConflict"
+	// KeyCollision binding keys read from different backing services collide
+	KeyCollision = "KeyCollision"
 	//Finalizer annotation used in finalizer steps
 	Finalizer = "finalizer.servicebindingrequest.openshift.io"
 	// time in seconds to wait before requeuing requests
@@ -40,19 +63,47 @@ func (b *ServiceBinder) message(err error) string {
 	return err.Error()
 }
 
+// reason converts the error to the Reason field in the Status condition.
+func (b *ServiceBinder) reason(err error) string {
+	if errors.Is(err, InvalidApplicationLabelSelectorErr) {
+		return InvalidApplicationLabelSelector
+	}
+	if errors.Is(err, UnknownKindErr) {
+		return UnknownKind
+	}
+	if errors.Is(err, ApplicationNamespaceNotAllowedErr) {
+		return ApplicationNamespaceNotAllowed
+	}
+	if errors.Is(err, SecretReplicaConflictErr) {
+		return SecretReplicaConflict
+	}
+	if errors.Is(err, ImmutableWorkloadErr) {
+		return ImmutableWorkload
+	}
+	return BindingFail
+}
+
 // ServiceBinderOptions is BuildServiceBinder arguments.
 type ServiceBinderOptions struct {
 	Logger                 *log.Log
 	DynClient              dynamic.Interface
+	RESTMapper             meta.RESTMapper
 	DetectBindingResources bool
 	EnvVarPrefix           string
 	SBR                    *v1alpha1.ServiceBindingRequest
 	Client                 client.Client
+	Recorder               record.EventRecorder
+	// Requests reads and writes the resource the SBR was translated from, ServiceBindingRequests
+	// when not informed.
+	Requests bindingRequests
+	// SecretKeyEnv are environment variables referring to intermediary secret keys, injected in
+	// application containers besides the binding itself.
+	SecretKeyEnv []corev1.EnvVar
 }
 
 // Valid returns whether the options are valid.
 func (o *ServiceBinderOptions) Valid() bool {
-	return o.SBR != nil && o.DynClient != nil && o.Client != nil
+	return o.SBR != nil && o.DynClient != nil && o.Client != nil && o.RESTMapper != nil
 }
 
 // ServiceBinder manages binding for a Service Binding Request and associated objects.
@@ -63,6 +114,8 @@ type ServiceBinder struct {
 	Data map[string][]byte
 	// DynClient is the Kubernetes dynamic client used to interact with the cluster.
 	DynClient dynamic.Interface
+	// RESTMapper maps the kinds of related objects to resources.
+	RESTMapper meta.RESTMapper
 	// Logger provides logging facilities for internal components.
 	Logger *log.Log
 	// Objects is a list of additional unstructured objects related to the Service Binding Request.
@@ -71,6 +124,17 @@ type ServiceBinder struct {
 	SBR *v1alpha1.ServiceBindingRequest
 	// Secret is the Secret associated with the Service Binding Request.
 	Secret *Secret
+	// Recorder is used to emit events on the Service Binding Request, when informed.
+	Recorder record.EventRecorder
+	// Dependencies are the objects the binding depends on, along with the status paths relevant
+	// for each, not available when unbinding.
+	Dependencies map[objectReference][]string
+	// Requests reads and writes the resource the SBR was translated from, ServiceBindingRequests
+	// when not informed.
+	Requests bindingRequests
+	// DeniedReads are the reads denied to the user requesting the binding, whose resources were
+	// skipped.
+	DeniedReads []v1alpha1.DeniedRead
 }
 
 // updateServiceBindingRequest execute update API call on a SBR request. It can return errors from
@@ -107,15 +171,15 @@ func updateServiceBindingRequest(
 func (b *ServiceBinder) updateServiceBindingRequest(
 	sbr *v1alpha1.ServiceBindingRequest,
 ) (*v1alpha1.ServiceBindingRequest, error) {
-	return updateServiceBindingRequest(b.DynClient, sbr)
+	return requestsOrDefault(b.Requests, b.DynClient).Update(sbr)
 }
 
 // Unbind removes the relationship between a Service Binding Request and its related objects.
 func (b *ServiceBinder) Unbind() (reconcile.Result, error) {
 	logger := b.Logger.WithName("Unbind")
 
-	logger.Info("Cleaning related objects from operator's annotations...")
-	if err := RemoveSBRAnnotations(b.DynClient, b.Objects); err != nil {
+	logger.Info("Cleaning related objects from annotations set by former operator versions...")
+	if err := RemoveSBRAnnotations(b.DynClient, b.RESTMapper, b.Objects); err != nil {
 		logger.Error(err, "On removing annotations from related objects.")
 		return RequeueError(err)
 	}
@@ -185,7 +249,7 @@ func (b *ServiceBinder) updateStatusServiceBindingRequest(
 	// coping status over informed object
 	sbr.Status = *sbrStatus
 
-	return updateServiceBindingRequestStatus(b.DynClient, sbr)
+	return requestsOrDefault(b.Requests, b.DynClient).UpdateStatus(sbr)
 }
 
 // onError comprise the update of ServiceBindingRequest status to set error flag, and inspect
@@ -203,7 +267,7 @@ func (b *ServiceBinder) onError(
 	conditionsv1.SetStatusCondition(&sbrStatus.Conditions, conditionsv1.Condition{
 		Type:    conditions.BindingReady,
 		Status:  corev1.ConditionFalse,
-		Reason:  BindingFail,
+		Reason:  b.reason(err),
 		Message: b.message(err),
 	})
 	sbrStatus.BindingStatus = BindingFail
@@ -213,12 +277,22 @@ func (b *ServiceBinder) onError(
 	}
 	b.SBR = newSbr
 
+	// an invalid selector won't change until the SBR is updated, which triggers a new reconciliation,
+	// and neither does an immutable workload until it's replaced, which also triggers one
+	if errors.Is(err, InvalidApplicationLabelSelectorErr) || errors.Is(err, ImmutableWorkloadErr) {
+		return Done()
+	}
+	// namespaces and secrets in those aren't watched, so those are inspected again later on
+	if errors.Is(err, ApplicationNamespaceNotAllowedErr) || errors.Is(err, SecretReplicaConflictErr) {
+		return Requeue(nil, requeueAfter)
+	}
 	return RequeueOnNotFound(err, requeueAfter)
 }
 
 // Bind configures binding between the Service Binding Request and its related objects.
 func (b *ServiceBinder) Bind() (reconcile.Result, error) {
 	sbrStatus := b.SBR.Status.DeepCopy()
+	sbrStatus.DeniedReads = b.DeniedReads
 
 	b.Logger.Info("Saving data on intermediary secret...")
 	secretObj, err := b.Secret.Commit(b.Data)
@@ -229,19 +303,14 @@ func (b *ServiceBinder) Bind() (reconcile.Result, error) {
 	sbrStatus.Secret = secretObj.GetName()
 
 	updatedObjects, err := b.Binder.Bind()
+	b.recordDrifts(sbrStatus)
+	b.recordSkipped()
 	if err != nil {
 		b.Logger.Error(err, "On binding application.")
 		return b.onError(err, b.SBR, sbrStatus, updatedObjects)
 	}
 	b.setApplicationObjects(sbrStatus, updatedObjects)
 
-	// annotating objects related to binding
-	namespacedName := types.NamespacedName{Namespace: b.SBR.GetNamespace(), Name: b.SBR.GetName()}
-	if err = SetSBRAnnotations(b.DynClient, namespacedName, append(b.Objects, secretObj)); err != nil {
-		b.Logger.Error(err, "On setting annotations in related objects.")
-		return b.onError(err, b.SBR, sbrStatus, updatedObjects)
-	}
-
 	sbrStatus.BindingStatus = BindingSuccess
 	conditionsv1.SetStatusCondition(&sbrStatus.Conditions, conditionsv1.Condition{
 		Type:   conditions.BindingReady,
@@ -264,12 +333,37 @@ func (b *ServiceBinder) Bind() (reconcile.Result, error) {
 	return Done()
 }
 
-// setApplicationObjects replaces the Status's equivalent field.
+// recordDrifts emits an event for each bound object found missing binding items during the last
+// bind, counting them in the informed status.
+func (b *ServiceBinder) recordDrifts(sbrStatus *v1alpha1.ServiceBindingRequestStatus) {
+	for _, drift := range b.Binder.drifts {
+		if b.Recorder != nil {
+			b.Recorder.Event(b.SBR, corev1.EventTypeWarning, BindingDrift, drift)
+		}
+	}
+	sbrStatus.DriftCount += int64(len(b.Binder.drifts))
+}
+
+// recordSkipped emits an event for each immutable workload selected during the last bind, which
+// was left unbound.
+func (b *ServiceBinder) recordSkipped() {
+	if b.Recorder == nil {
+		return
+	}
+	for _, skipped := range b.Binder.skipped {
+		b.Recorder.Event(b.SBR, corev1.EventTypeWarning, ImmutableWorkload,
+			fmt.Sprintf("%s: %s", ImmutableWorkloadErr, skipped))
+	}
+}
+
+// setApplicationObjects replaces the Status's equivalent field, along with the application objects
+// grouped by the application selector they were found by.
 func (b *ServiceBinder) setApplicationObjects(
 	sbrStatus *v1alpha1.ServiceBindingRequestStatus,
 	objs []*unstructured.Unstructured,
 ) {
 	boundApps := []v1alpha1.BoundApplication{}
+	bound := make(map[objectReference]v1alpha1.BoundApplication)
 	for _, obj := range objs {
 		boundApp := v1alpha1.BoundApplication{
 			GroupVersionKind: v1.GroupVersionKind{
@@ -281,19 +375,41 @@ func (b *ServiceBinder) setApplicationObjects(
 				Name: obj.GetName(),
 			},
 		}
+		if obj.GetNamespace() != b.SBR.GetNamespace() {
+			boundApp.Namespace = obj.GetNamespace()
+		}
 		boundApps = append(boundApps, boundApp)
+		bound[objectReferenceFor(obj.GroupVersionKind(), obj)] = boundApp
 	}
 	sbrStatus.ApplicationObjects = boundApps
+
+	groups := []v1alpha1.BoundApplicationGroup{}
+	for _, g := range b.Binder.groups {
+		group := v1alpha1.BoundApplicationGroup{Selector: *g.selector.DeepCopy()}
+		for _, ref := range g.refs {
+			if boundApp, found := bound[ref]; found {
+				group.Applications = append(group.Applications, boundApp)
+			}
+		}
+		groups = append(groups, group)
+	}
+	sbrStatus.ApplicationGroups = groups
 }
 
 // buildPlan creates a new plan.
 func buildPlan(
 	ctx context.Context,
 	dynClient dynamic.Interface,
+	restMapper meta.RESTMapper,
 	sbr *v1alpha1.ServiceBindingRequest,
 ) (*Plan, error) {
-	planner := NewPlanner(ctx, dynClient, sbr)
-	return planner.Plan()
+	planner := NewPlanner(ctx, dynClient, restMapper, sbr)
+	plan, err := planner.Plan()
+	if errors.Is(err, ReadAccessDeniedErr) {
+		// recorded for the status update of the caller, since no binding takes place
+		sbr.Status.DeniedReads = planner.DeniedReads()
+	}
+	return plan, err
 }
 
 // InvalidOptionsErr is returned when ServiceBinderOptions are not valid.
@@ -310,7 +426,7 @@ func BuildServiceBinder(options *ServiceBinderOptions) (*ServiceBinder, error) {
 
 	// plan is a source of information regarding the binding process
 	ctx := context.Background()
-	plan, err := buildPlan(ctx, options.DynClient, options.SBR)
+	plan, err := buildPlan(ctx, options.DynClient, options.RESTMapper, options.SBR)
 	if err != nil {
 		return nil, err
 	}
@@ -322,17 +438,25 @@ func BuildServiceBinder(options *ServiceBinderOptions) (*ServiceBinder, error) {
 	// retriever is responsible for gathering data related to the given plan.
 	retriever := NewRetriever(options.DynClient, plan, options.EnvVarPrefix)
 
-	// read bindable data from the specified resources
+	// read bindable data from the specified resources, other than Provisioned Services and core
+	// kinds
 	if options.DetectBindingResources {
-		err := retriever.ReadBindableResourcesData(&plan.SBR, rs)
+		err := retriever.ReadBindableResourcesData(&plan.SBR, plan.RelatedResources.GetDescribedCRs())
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	// read bindable data from the CRDDescription found by the planner
+	// read bindable data from the binding secret of Provisioned Services, using built-in rules for
+	// core kinds, otherwise from the CRDDescription found by the planner
 	for _, r := range plan.GetRelatedResources() {
-		err = retriever.ReadCRDDescriptionData(r.CR, r.CRDDescription)
+		if r.BindingSecret != "" {
+			err = retriever.ReadBindingSecret(r.CR, r.BindingSecret)
+		} else if isCoreBackingService(r.CR.GroupVersionKind().GroupKind()) {
+			err = retriever.ReadCoreBackingServiceData(r.CR)
+		} else {
+			err = retriever.ReadCRDDescriptionData(r.CR, r.CRDDescription)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -345,16 +469,125 @@ func BuildServiceBinder(options *ServiceBinderOptions) (*ServiceBinder, error) {
 		return nil, err
 	}
 
+	// binding libraries expect the binding type and provider when bound as files
+	if options.SBR.Spec.BindAsFiles {
+		addBindingFileEntries(retrievedData, plan)
+	}
+
 	// gather related secret, again only appending it if there's a value.
 	secret := NewSecret(options.DynClient, plan)
 
+	// binder restarts workloads based on the data hash, so only changes in data trigger restarts
+	binder := NewBinder(
+		ctx,
+		options.Client,
+		options.DynClient,
+		options.RESTMapper,
+		options.SBR,
+		retriever.VolumeKeys,
+	)
+	binder.dataHash = hashData(retrievedData)
+	binder.secretKeyEnv = options.SecretKeyEnv
+
+	return &ServiceBinder{
+		Logger:       options.Logger,
+		Binder:       binder,
+		DynClient:    options.DynClient,
+		RESTMapper:   options.RESTMapper,
+		SBR:          options.SBR,
+		Objects:      objs,
+		Data:         retrievedData,
+		Secret:       secret,
+		Recorder:     options.Recorder,
+		Dependencies: dependenciesFromPlan(plan, retriever.Objects),
+		Requests:     options.Requests,
+		DeniedReads:  retriever.DeniedReads(),
+	}, nil
+}
+
+// searchBoundBackingServices returns the backing service CRs referred by the SBR that still exist,
+// skipping the ones that have been removed, including the ones whose CRD is not present anymore.
+func searchBoundBackingServices(
+	dynClient dynamic.Interface,
+	restMapper meta.RESTMapper,
+	sbr *v1alpha1.ServiceBindingRequest,
+) ([]*unstructured.Unstructured, error) {
+	var selectors []v1alpha1.BackingServiceSelector
+	if sbr.Spec.BackingServiceSelector != nil {
+		selectors = append(selectors, *sbr.Spec.BackingServiceSelector)
+	}
+	if sbr.Spec.BackingServiceSelectors != nil {
+		selectors = append(selectors, *sbr.Spec.BackingServiceSelectors...)
+	}
+
+	objs := make([]*unstructured.Unstructured, 0)
+	for _, s := range selectors {
+		ns := sbr.GetNamespace()
+		if s.Namespace != nil {
+			ns = *s.Namespace
+		}
+		gvk := schema.GroupVersionKind{Group: s.Group, Version: s.Version, Kind: s.Kind}
+		gvr, err := resourceForKind(restMapper, gvk)
+		if errors.Is(err, UnknownKindErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		u, err := dynClient.Resource(gvr).Namespace(ns).Get(s.ResourceRef, v1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, u)
+	}
+	return objs, nil
+}
+
+// BuildServiceUnbinder creates a new binding manager meant to unbind the informed SBR. Unlike
+// BuildServiceBinder, the binding is not planned; it relies only on what has been recorded in the
+// SBR when binding, therefore backing services and their CRDs are not required to exist.
+func BuildServiceUnbinder(options *ServiceBinderOptions) (*ServiceBinder, error) {
+	if !options.Valid() {
+		return nil, InvalidOptionsErr
+	}
+
+	// objs are the backing service CRs still present, to have operator's annotations removed
+	objs, err := searchBoundBackingServices(options.DynClient, options.RESTMapper, options.SBR)
+	if err != nil {
+		return nil, err
+	}
+
+	// secret name recorded in status, otherwise the name used when binding
+	secretName := options.SBR.Status.Secret
+	if secretName == "" {
+		secretName = options.SBR.GetName()
+	}
+	plan := &Plan{
+		Name: secretName,
+		Ns:   options.SBR.GetNamespace(),
+		SBR:  *options.SBR,
+	}
+
+	ctx := context.Background()
 	return &ServiceBinder{
-		Logger:    options.Logger,
-		Binder:    NewBinder(ctx, options.Client, options.DynClient, options.SBR, retriever.VolumeKeys),
-		DynClient: options.DynClient,
-		SBR:       options.SBR,
-		Objects:   objs,
-		Data:      retrievedData,
-		Secret:    secret,
+		Logger: options.Logger,
+		Binder: NewBinder(
+			ctx,
+			options.Client,
+			options.DynClient,
+			options.RESTMapper,
+			options.SBR,
+			[]VolumeKey{},
+		),
+		DynClient:  options.DynClient,
+		RESTMapper: options.RESTMapper,
+		SBR:        options.SBR,
+		Objects:    objs,
+		Secret:     NewSecret(options.DynClient, plan),
+		Requests:   options.Requests,
 	}, nil
 }
@@ -14,6 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic/fake"
 	k8stesting "k8s.io/client-go/testing"
 
@@ -237,7 +238,8 @@ func TestServiceBinder_Bind(t *testing.T) {
 			Kind:       "ServiceBindingRequest",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: "single-sbr",
+			Name:      "single-sbr",
+			Namespace: reconcilerName,
 		},
 		Spec: v1alpha1.ServiceBindingRequestSpec{
 			ApplicationSelector: v1alpha1.ApplicationSelector{
@@ -271,7 +273,8 @@ func TestServiceBinder_Bind(t *testing.T) {
 			Kind:       "ServiceBindingRequest",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: "single-sbr-with-customenvvar",
+			Name:      "single-sbr-with-customenvvar",
+			Namespace: reconcilerName,
 		},
 		Spec: v1alpha1.ServiceBindingRequestSpec{
 			ApplicationSelector: v1alpha1.ApplicationSelector{
@@ -317,7 +320,8 @@ func TestServiceBinder_Bind(t *testing.T) {
 			Kind:       "ServiceBindingRequest",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: "multiple-sbr",
+			Name:      "multiple-sbr",
+			Namespace: reconcilerName,
 		},
 		Spec: v1alpha1.ServiceBindingRequestSpec{
 			ApplicationSelector: v1alpha1.ApplicationSelector{
@@ -339,6 +343,7 @@ func TestServiceBinder_Bind(t *testing.T) {
 						Kind:    db1.GetObjectKind().GroupVersionKind().Kind,
 					},
 					ResourceRef: db1.GetName(),
+					ID:          "primary",
 				},
 				{
 					GroupVersionKind: metav1.GroupVersionKind{
@@ -347,6 +352,7 @@ func TestServiceBinder_Bind(t *testing.T) {
 						Kind:    db2.GetObjectKind().GroupVersionKind().Kind,
 					},
 					ResourceRef: db2.GetName(),
+					ID:          "replica",
 				},
 			},
 		},
@@ -354,13 +360,22 @@ func TestServiceBinder_Bind(t *testing.T) {
 	}
 	f.AddMockResource(sbrMultipleServices)
 
+	// services of the same kind not identified have their keys colliding
+	sbrCollidingServices := sbrMultipleServices.DeepCopy()
+	sbrCollidingServices.SetName("colliding-sbr")
+	for i := range *sbrCollidingServices.Spec.BackingServiceSelectors {
+		(*sbrCollidingServices.Spec.BackingServiceSelectors)[i].ID = ""
+	}
+	f.AddMockResource(sbrCollidingServices)
+
 	sbrEmptyAppSelector := &v1alpha1.ServiceBindingRequest{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apps.openshift.io/v1alpha1",
 			Kind:       "ServiceBindingRequest",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: "empty-app-selector",
+			Name:      "empty-app-selector",
+			Namespace: reconcilerName,
 		},
 		Spec: v1alpha1.ServiceBindingRequestSpec{
 			ApplicationSelector: v1alpha1.ApplicationSelector{},
@@ -385,7 +400,8 @@ func TestServiceBinder_Bind(t *testing.T) {
 			Kind:       "ServiceBindingRequest",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: "empty-bss",
+			Name:      "empty-bss",
+			Namespace: reconcilerName,
 		},
 		Spec: v1alpha1.ServiceBindingRequestSpec{
 			ApplicationSelector: v1alpha1.ApplicationSelector{
@@ -405,12 +421,23 @@ func TestServiceBinder_Bind(t *testing.T) {
 	}
 	f.AddMockResource(sbrEmptyBackingServiceSelector)
 
+	sbrInvalidAppLabelSelector := sbrSingleService.DeepCopy()
+	sbrInvalidAppLabelSelector.SetName("invalid-app-label-selector")
+	sbrInvalidAppLabelSelector.Spec.ApplicationSelector.ResourceRef = ""
+	sbrInvalidAppLabelSelector.Spec.ApplicationSelector.LabelSelector = &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "environment", Operator: "Unknown", Values: []string{"binding"}},
+		},
+	}
+	f.AddMockResource(sbrInvalidAppLabelSelector)
+
 	logger := log.NewLog("service-binder")
 
 	t.Run("single bind golden path", assertBind(args{
 		options: &ServiceBinderOptions{
 			Logger:                 logger,
 			DynClient:              f.FakeDynClient(),
+			RESTMapper:             f.FakeRESTMapper(),
 			DetectBindingResources: false,
 			EnvVarPrefix:           "",
 			SBR:                    sbrSingleService,
@@ -445,6 +472,7 @@ func TestServiceBinder_Bind(t *testing.T) {
 		options: &ServiceBinderOptions{
 			Logger:                 logger,
 			DynClient:              f.FakeDynClient(),
+			RESTMapper:             f.FakeRESTMapper(),
 			DetectBindingResources: false,
 			EnvVarPrefix:           "",
 			SBR:                    sbrSingleServiceWithCustomEnvVar,
@@ -482,6 +510,7 @@ func TestServiceBinder_Bind(t *testing.T) {
 		options: &ServiceBinderOptions{
 			Logger:                 logger,
 			DynClient:              f.FakeDynClient(),
+			RESTMapper:             f.FakeRESTMapper(),
 			DetectBindingResources: true,
 			EnvVarPrefix:           "",
 			SBR:                    sbrSingleService,
@@ -499,6 +528,7 @@ func TestServiceBinder_Bind(t *testing.T) {
 		options: &ServiceBinderOptions{
 			Logger:                 logger,
 			DynClient:              f.FakeDynClient(),
+			RESTMapper:             f.FakeRESTMapper(),
 			DetectBindingResources: true,
 			EnvVarPrefix:           "",
 			SBR:                    sbrEmptyAppSelector,
@@ -515,10 +545,30 @@ func TestServiceBinder_Bind(t *testing.T) {
 		},
 	}))
 
+	t.Run("invalid application label selector", assertBind(args{
+		options: &ServiceBinderOptions{
+			Logger:                 logger,
+			DynClient:              f.FakeDynClient(),
+			RESTMapper:             f.FakeRESTMapper(),
+			DetectBindingResources: false,
+			EnvVarPrefix:           "",
+			SBR:                    sbrInvalidAppLabelSelector,
+			Client:                 f.FakeClient(),
+		},
+		wantConditions: []wantedCondition{
+			{
+				Type:   conditions.BindingReady,
+				Status: corev1.ConditionFalse,
+				Reason: InvalidApplicationLabelSelector,
+			},
+		},
+	}))
+
 	t.Run("empty backingServiceSelector", assertBind(args{
 		options: &ServiceBinderOptions{
 			Logger:                 logger,
 			DynClient:              f.FakeDynClient(),
+			RESTMapper:             f.FakeRESTMapper(),
 			DetectBindingResources: true,
 			EnvVarPrefix:           "",
 			SBR:                    sbrEmptyBackingServiceSelector,
@@ -540,6 +590,7 @@ func TestServiceBinder_Bind(t *testing.T) {
 		options: &ServiceBinderOptions{
 			Logger:                 logger,
 			DynClient:              f.FakeDynClient(),
+			RESTMapper:             f.FakeRESTMapper(),
 			DetectBindingResources: false,
 			EnvVarPrefix:           "",
 			SBR:                    nil,
@@ -552,6 +603,7 @@ func TestServiceBinder_Bind(t *testing.T) {
 		options: &ServiceBinderOptions{
 			Logger:                 logger,
 			DynClient:              f.FakeDynClient(),
+			RESTMapper:             f.FakeRESTMapper(),
 			DetectBindingResources: false,
 			EnvVarPrefix:           "",
 			SBR:                    sbrMultipleServices,
@@ -586,4 +638,67 @@ func TestServiceBinder_Bind(t *testing.T) {
 			},
 		},
 	}))
+
+	t.Run("multiple services colliding keys", assertBind(args{
+		options: &ServiceBinderOptions{
+			Logger:     logger,
+			DynClient:  f.FakeDynClient(),
+			RESTMapper: f.FakeRESTMapper(),
+			SBR:        sbrCollidingServices,
+			Client:     f.FakeClient(),
+		},
+		wantBuildErr: KeyCollisionErr,
+	}))
+}
+
+func TestServiceBinderSetApplicationObjects(t *testing.T) {
+	ns := "service-binder"
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	sbr := mocks.ServiceBindingRequestMock(ns, "sbr", nil, "db", "app", deploymentsGVR, nil)
+
+	// application returns a deployment object with the informed namespace and name.
+	application := func(namespace, name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(deploymentGVK)
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		return u
+	}
+	app := application(ns, "app")
+	other := application("tenant", "other")
+
+	byName := sbr.Spec.ApplicationSelector
+	byLabels := v1alpha1.ApplicationSelector{
+		GroupVersionResource: metav1.GroupVersionResource(deploymentsGVR),
+		LabelSelector:        &metav1.LabelSelector{MatchLabels: map[string]string{"connects-to": "database"}},
+		NamespaceSelector:    &metav1.LabelSelector{},
+	}
+	sb := &ServiceBinder{
+		SBR: sbr,
+		Binder: &Binder{groups: []applicationGroup{
+			{selector: byName, refs: []objectReference{objectReferenceFor(deploymentGVK, app)}},
+			{selector: byLabels, refs: []objectReference{
+				objectReferenceFor(deploymentGVK, app),
+				objectReferenceFor(deploymentGVK, other),
+			}},
+		}},
+	}
+
+	status := &v1alpha1.ServiceBindingRequestStatus{}
+	sb.setApplicationObjects(status, []*unstructured.Unstructured{app, other})
+
+	boundApp := v1alpha1.BoundApplication{
+		GroupVersionKind:     metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		LocalObjectReference: corev1.LocalObjectReference{Name: "app"},
+	}
+	boundOther := v1alpha1.BoundApplication{
+		GroupVersionKind:     metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		LocalObjectReference: corev1.LocalObjectReference{Name: "other"},
+		Namespace:            "tenant",
+	}
+	require.Equal(t, []v1alpha1.BoundApplication{boundApp, boundOther}, status.ApplicationObjects)
+	require.Equal(t, []v1alpha1.BoundApplicationGroup{
+		{Selector: byName, Applications: []v1alpha1.BoundApplication{boundApp}},
+		{Selector: byLabels, Applications: []v1alpha1.BoundApplication{boundApp, boundOther}},
+	}, status.ApplicationGroups)
 }
@@ -21,6 +21,16 @@ func NewBindingInfo(name string, value string) (*BindingInfo, error) {
 	cleanName := strings.TrimPrefix(name, ServiceBindingOperatorAnnotationPrefix)
 	parts := strings.SplitN(cleanName, "-", 2)
 
+	// if there is only one part and the annotation refers to another manifest, all of its items
+	// will be used
+	if len(parts) == 1 && isObjectDescriptor(value) {
+		return &BindingInfo{
+			FieldPath:  parts[0],
+			Path:       allItems,
+			Descriptor: strings.Join([]string{value, allItems}, ":"),
+		}, nil
+	}
+
 	// if there is only one part, it means the value of the referenced field itself will be used
 	if len(parts) == 1 {
 		return &BindingInfo{
@@ -37,6 +37,16 @@ func TestNewBindingInfo(t *testing.T) {
 			name:    "{path} annotation",
 			wantErr: false,
 		},
+		{
+			args: args{s: "status.dbCredentials", d: "binding:env:object:secret"},
+			want: &BindingInfo{
+				Descriptor: "binding:env:object:secret:*",
+				FieldPath:  "status.dbCredentials",
+				Path:       "*",
+			},
+			name:    "{fieldPath} annotation referring to all items",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,183 @@
+package servicebindingrequest
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+)
+
+// objectReference identifies an object in the cluster, regardless of its version.
+type objectReference struct {
+	Group     string
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// objectReferenceFor returns the reference of the informed object, having the informed kind.
+func objectReferenceFor(gvk schema.GroupVersionKind, obj metav1.Object) objectReference {
+	return objectReference{
+		Group:     gvk.Group,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+}
+
+// bindableServiceGVK is the kind of the BindableServices describing backing service kinds.
+var bindableServiceGVK = v1alpha1.SchemeGroupVersion.WithKind("BindableService")
+
+// bindableServiceReferenceFor returns the reference standing for any BindableService describing the
+// informed kind, since those may be created, changed or deleted regardless of their names.
+func bindableServiceReferenceFor(gk schema.GroupKind) objectReference {
+	return objectReference{
+		Group: bindableServiceGVK.Group,
+		Kind:  bindableServiceGVK.Kind,
+		Name:  strings.ToLower(gk.String()),
+	}
+}
+
+// wholeStatusPath is the status path meaning the whole status section is relevant.
+const wholeStatusPath = ""
+
+// secretGVK is the kind of the intermediary secret.
+var secretGVK = schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"}
+
+// dependenciesFromPlan returns the objects a binding depends on: the backing service CRs in the
+// plan, along with the status paths binding data is read from, as declared in the CRDDescription's
+// status descriptors, or the binding secret path of Provisioned Services; the informed objects read
+// while retrieving binding data, such as secrets and config maps; the BindableServices describing
+// the backing service kinds resolved through CRDs; and the intermediary secret. Custom environment
+// variables may refer to any status field, so the whole status is relevant when those are informed.
+func dependenciesFromPlan(plan *Plan, retrieved []*unstructured.Unstructured) map[objectReference][]string {
+	objs := make(map[objectReference][]string)
+	for _, r := range plan.RelatedResources {
+		paths := []string{}
+		if len(plan.SBR.Spec.CustomEnvVar) > 0 {
+			paths = append(paths, wholeStatusPath)
+		} else if r.BindingSecret != "" {
+			paths = append(paths, bindingSecretStatusPath)
+		} else if r.CRDDescription != nil {
+			for _, d := range r.CRDDescription.StatusDescriptors {
+				paths = append(paths, d.Path)
+			}
+		}
+		objs[objectReferenceFor(r.CR.GroupVersionKind(), r.CR)] = paths
+		if gk := r.CR.GroupVersionKind().GroupKind(); r.BindingSecret == "" && !isCoreBackingService(gk) {
+			objs[bindableServiceReferenceFor(gk)] = []string{}
+		}
+	}
+	for _, obj := range retrieved {
+		objs[objectReferenceFor(obj.GroupVersionKind(), obj)] = []string{}
+	}
+	secret := &metav1.ObjectMeta{Namespace: plan.Ns, Name: plan.Name}
+	objs[objectReferenceFor(secretGVK, secret)] = []string{}
+	return objs
+}
+
+// boundObjectIndex is a reverse index from objects bindings depend on, such as backing service CRs
+// and their secrets, to the service binding requests depending on them, along with the status paths
+// each of those depends on. It's safe for concurrent use, and replaces annotating those objects,
+// which are usually owned by other operators, with the SBR they belong to.
+type boundObjectIndex struct {
+	lock    sync.RWMutex
+	objects map[objectReference]map[types.NamespacedName][]string
+	sbrs    map[types.NamespacedName][]objectReference
+}
+
+// newBoundObjectIndex returns an empty boundObjectIndex.
+func newBoundObjectIndex() *boundObjectIndex {
+	return &boundObjectIndex{
+		objects: make(map[objectReference]map[types.NamespacedName][]string),
+		sbrs:    make(map[types.NamespacedName][]objectReference),
+	}
+}
+
+// deleteLocked removes all entries of the informed SBR, expecting the lock to be held.
+func (i *boundObjectIndex) deleteLocked(sbr types.NamespacedName) {
+	for _, ref := range i.sbrs[sbr] {
+		delete(i.objects[ref], sbr)
+		if len(i.objects[ref]) == 0 {
+			delete(i.objects, ref)
+		}
+	}
+	delete(i.sbrs, sbr)
+}
+
+// set replaces the objects the informed SBR depends on, with the status paths relevant for each.
+func (i *boundObjectIndex) set(sbr types.NamespacedName, objs map[objectReference][]string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.deleteLocked(sbr)
+	for ref, paths := range objs {
+		if _, ok := i.objects[ref]; !ok {
+			i.objects[ref] = make(map[types.NamespacedName][]string)
+		}
+		i.objects[ref][sbr] = paths
+		i.sbrs[sbr] = append(i.sbrs[sbr], ref)
+	}
+}
+
+// delete removes all entries of the informed SBR.
+func (i *boundObjectIndex) delete(sbr types.NamespacedName) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.deleteLocked(sbr)
+}
+
+// lookup returns the namespaced names of the SBRs depending on the informed object.
+func (i *boundObjectIndex) lookup(ref objectReference) []types.NamespacedName {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	result := []types.NamespacedName{}
+	for sbr := range i.objects[ref] {
+		result = append(result, sbr)
+	}
+	// map iteration order is random, sorting keeps results stable
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].String() < result[b].String()
+	})
+	return result
+}
+
+// statusPaths returns the status paths relevant for any SBR depending on the informed object, and
+// whether the object is indexed at all.
+func (i *boundObjectIndex) statusPaths(ref objectReference) ([]string, bool) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	sbrs, ok := i.objects[ref]
+	if !ok {
+		return nil, false
+	}
+	set := make(map[string]bool)
+	for _, paths := range sbrs {
+		for _, p := range paths {
+			set[p] = true
+		}
+	}
+	paths := make([]string, 0, len(set))
+	for p := range set {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths, true
+}
+
+// statusPathFields returns the fields to reach the informed status path.
+func statusPathFields(path string) []string {
+	if path == wholeStatusPath {
+		return []string{"status"}
+	}
+	// JSONPaths are compared as of their leading plain fields
+	return append([]string{"status"}, leadingPathFields(path)...)
+}
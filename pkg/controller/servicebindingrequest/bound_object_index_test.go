@@ -0,0 +1,178 @@
+package servicebindingrequest
+
+import (
+	"testing"
+
+	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/converter"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestBoundObjectIndex(t *testing.T) {
+	ns := "bound-object-index"
+	db := objectReference{Group: "postgresql.baiju.dev", Kind: "Database", Namespace: ns, Name: "db"}
+	cache := objectReference{Group: "cache.example.com", Kind: "Cache", Namespace: ns, Name: "cache"}
+	sbrA := types.NamespacedName{Namespace: ns, Name: "a"}
+	sbrB := types.NamespacedName{Namespace: ns, Name: "b"}
+
+	index := newBoundObjectIndex()
+	index.set(sbrA, map[objectReference][]string{db: {"dbCredentials"}, cache: {"host"}})
+	index.set(sbrB, map[objectReference][]string{db: {"dbConnectionIP", "dbCredentials"}})
+
+	paths, found := index.statusPaths(db)
+	require.True(t, found)
+	require.Equal(t, []string{"dbConnectionIP", "dbCredentials"}, paths)
+
+	// replacing the entries of a SBR removes the objects it doesn't depend on anymore
+	index.set(sbrA, map[objectReference][]string{db: {"dbCredentials"}})
+	_, found = index.statusPaths(cache)
+	require.False(t, found)
+
+	index.delete(sbrB)
+	paths, found = index.statusPaths(db)
+	require.True(t, found)
+	require.Equal(t, []string{"dbCredentials"}, paths)
+
+	index.delete(sbrA)
+	_, found = index.statusPaths(db)
+	require.False(t, found)
+}
+
+func TestDependenciesFromPlan(t *testing.T) {
+	ns := "bound-object-index"
+	cr, err := mocks.UnstructuredDatabaseCRMock(ns, "db")
+	require.NoError(t, err)
+	crdDescription := mocks.CRDDescriptionMock()
+	sbr := mocks.ServiceBindingRequestMock(ns, "sbr", nil, "db", "", deploymentsGVR, nil)
+	sbr.Spec.CustomEnvVar = nil
+	plan := &Plan{
+		Ns:               ns,
+		Name:             "sbr",
+		SBR:              *sbr,
+		RelatedResources: RelatedResources{{CR: cr, CRDDescription: &crdDescription}},
+	}
+	credentials, err := converter.ToUnstructured(mocks.SecretMock(ns, "db-credentials"))
+	require.NoError(t, err)
+
+	ref := objectReferenceFor(cr.GroupVersionKind(), cr)
+	credentialsRef := objectReference{Kind: "Secret", Namespace: ns, Name: "db-credentials"}
+	intermediarySecretRef := objectReference{Kind: "Secret", Namespace: ns, Name: "sbr"}
+	bindableServiceRef := objectReference{
+		Group: "apps.openshift.io",
+		Kind:  "BindableService",
+		Name:  "database.postgresql.baiju.dev",
+	}
+
+	t.Run("status descriptors", func(t *testing.T) {
+		expected := []string{}
+		for _, d := range crdDescription.StatusDescriptors {
+			expected = append(expected, d.Path)
+		}
+		require.Equal(t, map[objectReference][]string{
+			ref:                   expected,
+			credentialsRef:        {},
+			bindableServiceRef:    {},
+			intermediarySecretRef: {},
+		}, dependenciesFromPlan(plan, []*unstructured.Unstructured{credentials}))
+	})
+
+	t.Run("provisioned service", func(t *testing.T) {
+		p := *plan
+		p.RelatedResources = RelatedResources{{CR: cr, BindingSecret: "db-credentials"}}
+		require.Equal(t, map[objectReference][]string{
+			ref:                   {bindingSecretStatusPath},
+			credentialsRef:        {},
+			intermediarySecretRef: {},
+		}, dependenciesFromPlan(&p, []*unstructured.Unstructured{credentials}))
+	})
+
+	t.Run("no status descriptors", func(t *testing.T) {
+		p := *plan
+		p.RelatedResources = RelatedResources{{CR: cr, CRDDescription: &olmv1alpha1.CRDDescription{}}}
+		require.Equal(t, map[objectReference][]string{
+			ref:                   {},
+			bindableServiceRef:    {},
+			intermediarySecretRef: {},
+		}, dependenciesFromPlan(&p, nil))
+	})
+
+	t.Run("custom env vars", func(t *testing.T) {
+		p := *plan
+		p.SBR.Spec.CustomEnvVar = []corev1.EnvVar{{Name: "HOST", Value: "{{ .status.dbConnectionIP }}"}}
+		require.Equal(t, map[objectReference][]string{
+			ref:                   {wholeStatusPath},
+			bindableServiceRef:    {},
+			intermediarySecretRef: {},
+		}, dependenciesFromPlan(&p, nil))
+	})
+}
+
+func TestStatusPathsChanged(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"dbCredentials":  "db-credentials",
+			"dbConnectionIP": "172.30.0.1",
+			"connection":     map[string]interface{}{"port": int64(5432)},
+		},
+	}}
+
+	tests := []struct {
+		name   string
+		paths  []string
+		modify func(u *unstructured.Unstructured)
+		want   bool
+	}{
+		{
+			name:  "unrelated path changed",
+			paths: []string{"dbCredentials"},
+			modify: func(u *unstructured.Unstructured) {
+				u.Object["status"].(map[string]interface{})["dbConnectionIP"] = "172.30.0.2"
+			},
+			want: false,
+		},
+		{
+			name:  "path changed",
+			paths: []string{"dbCredentials", "dbConnectionIP"},
+			modify: func(u *unstructured.Unstructured) {
+				u.Object["status"].(map[string]interface{})["dbConnectionIP"] = "172.30.0.2"
+			},
+			want: true,
+		},
+		{
+			name:  "nested path changed",
+			paths: []string{"connection.port"},
+			modify: func(u *unstructured.Unstructured) {
+				require.NoError(t, unstructured.SetNestedField(u.Object, int64(5433), "status", "connection", "port"))
+			},
+			want: true,
+		},
+		{
+			name:  "path removed",
+			paths: []string{"dbCredentials"},
+			modify: func(u *unstructured.Unstructured) {
+				delete(u.Object["status"].(map[string]interface{}), "dbCredentials")
+			},
+			want: true,
+		},
+		{
+			name:   "whole status",
+			paths:  []string{wholeStatusPath},
+			modify: func(u *unstructured.Unstructured) { u.Object["status"].(map[string]interface{})["phase"] = "Ready" },
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed := u.DeepCopy()
+			tt.modify(changed)
+			got, err := statusPathsChanged(u, changed, tt.paths)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
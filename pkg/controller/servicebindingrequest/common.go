@@ -12,6 +12,12 @@ const (
 	ServiceBindingRequestResource = "servicebindingrequests"
 	// ServiceBindingRequestKind defines the name of the CRD kind.
 	ServiceBindingRequestKind = "ServiceBindingRequest"
+	// ServiceBindingResource the name of servicebinding.io ServiceBinding resource.
+	ServiceBindingResource = "servicebindings"
+	// ServiceBindingKind defines the name of servicebinding.io ServiceBinding kind.
+	ServiceBindingKind = "ServiceBinding"
+	// BindableServiceResource the name of BindableService resource.
+	BindableServiceResource = "bindableservices"
 	// DeploymentConfigKind defines the name of DeploymentConfig kind.
 	DeploymentConfigKind = "DeploymentConfig"
 	// ClusterServiceVersionKind the name of ClusterServiceVersion kind.
@@ -1,38 +1,79 @@
 package servicebindingrequest
 
 import (
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-// Add creates a new ServiceBindingRequest Controller and adds it to the Manager. The Manager will
-// set fields on the Controller and Start it when the Manager is Started.
+// Add creates the ServiceBindingRequest and ServiceBinding Controllers and adds them to the Manager.
+// The Manager will set fields on the Controllers and Start them when the Manager is Started.
 func Add(mgr manager.Manager) error {
 	client, err := dynamic.NewForConfig(mgr.GetConfig())
 	if err != nil {
 		return err
 	}
-	r, err := newReconciler(mgr, client)
+	// restMapper is shared by the reconciler and the controller, which resets it when CRDs change
+	restMapper, err := NewRESTMapper(mgr.GetConfig())
 	if err != nil {
 		return err
 	}
-	return add(mgr, r, client)
+	// both kinds are handled by the same binding pipeline, ServiceBindings being translated into
+	// ServiceBindingRequests, and reconciled by distinct controllers since their names may overlap
+	for _, requests := range []bindingRequests{
+		&sbrRequests{dynClient: client},
+		&serviceBindingRequests{dynClient: client, restMapper: restMapper},
+	} {
+		// boundObjects is filled by the reconciler and read by the controller's predicates
+		boundObjects := newBoundObjectIndex()
+		r, err := newReconciler(mgr, client, restMapper, boundObjects, requests)
+		if err != nil {
+			return err
+		}
+		if err = add(mgr, r, client, restMapper, boundObjects, requests); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager, client dynamic.Interface) (reconcile.Reconciler, error) {
-	return &Reconciler{client: mgr.GetClient(), dynClient: client, scheme: mgr.GetScheme()}, nil
+func newReconciler(
+	mgr manager.Manager,
+	client dynamic.Interface,
+	restMapper meta.RESTMapper,
+	boundObjects *boundObjectIndex,
+	requests bindingRequests,
+) (reconcile.Reconciler, error) {
+	return &Reconciler{
+		client:       mgr.GetClient(),
+		dynClient:    client,
+		restMapper:   restMapper,
+		scheme:       mgr.GetScheme(),
+		recorder:     mgr.GetEventRecorderFor(controllerName(requests.GroupVersionKind())),
+		boundObjects: boundObjects,
+		requests:     requests,
+	}, nil
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler.
-func add(mgr manager.Manager, r reconcile.Reconciler, client dynamic.Interface) error {
+func add(
+	mgr manager.Manager,
+	r reconcile.Reconciler,
+	client dynamic.Interface,
+	restMapper meta.RESTMapper,
+	boundObjects *boundObjectIndex,
+	requests bindingRequests,
+) error {
 	opts := controller.Options{Reconciler: r}
-	c, err := NewSBRController(mgr, opts, client)
+	c, err := NewSBRController(mgr, opts, client, requests)
 	if err != nil {
 		return err
 	}
+	c.RESTMapper = restMapper
+	c.boundObjects = boundObjects
 	return c.Watch()
 }
 
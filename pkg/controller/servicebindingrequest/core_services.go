@@ -0,0 +1,122 @@
+package servicebindingrequest
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// coreBindingDataFunc extracts binding data from a backing service of a core kind.
+type coreBindingDataFunc func(obj *unstructured.Unstructured) (map[string][]byte, error)
+
+// coreBackingServices are the built-in extraction rules of the core kinds allowed as backing
+// services, which have neither CRDs nor OLM descriptors describing their binding data.
+var coreBackingServices = map[schema.GroupKind]coreBindingDataFunc{
+	{Group: "", Kind: SecretKind}:                secretBindingData,
+	{Group: "", Kind: "ConfigMap"}:               configMapBindingData,
+	{Group: "", Kind: "Service"}:                 serviceBindingData,
+	{Group: "route.openshift.io", Kind: "Route"}: routeBindingData,
+}
+
+// isCoreBackingService evaluates whether the informed kind is a core kind having built-in
+// extraction rules.
+func isCoreBackingService(gk schema.GroupKind) bool {
+	_, ok := coreBackingServices[gk]
+	return ok
+}
+
+// secretBindingData returns all keys of the informed secret.
+func secretBindingData(obj *unstructured.Unstructured) (map[string][]byte, error) {
+	data, _, err := unstructured.NestedStringMap(obj.Object, "data")
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]byte, len(data))
+	for k, v := range data {
+		value, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, err
+		}
+		result[k] = value
+	}
+	return result, nil
+}
+
+// configMapBindingData returns all keys of the informed config map, including the binary ones.
+func configMapBindingData(obj *unstructured.Unstructured) (map[string][]byte, error) {
+	return configMapData(obj)
+}
+
+// serviceBindingData returns the cluster DNS host, the cluster IP and the ports of the informed
+// service. The first port is returned as "port", and named ports as "port_<name>" as well.
+func serviceBindingData(obj *unstructured.Unstructured) (map[string][]byte, error) {
+	result := map[string][]byte{
+		"host": []byte(fmt.Sprintf("%s.%s.svc", obj.GetName(), obj.GetNamespace())),
+	}
+	clusterIP, found, err := unstructured.NestedString(obj.Object, "spec", "clusterIP")
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		result["clusterIP"] = []byte(clusterIP)
+	}
+
+	ports, _, err := unstructured.NestedSlice(obj.Object, "spec", "ports")
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range ports {
+		port, ok := p.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected port in service '%s'", obj.GetName())
+		}
+		number, _, err := unstructured.NestedInt64(port, "port")
+		if err != nil {
+			return nil, err
+		}
+		value := []byte(strconv.FormatInt(number, 10))
+		if i == 0 {
+			result["port"] = value
+		}
+		if name, _, _ := unstructured.NestedString(port, "name"); name != "" {
+			result["port_"+name] = value
+		}
+	}
+	return result, nil
+}
+
+// routeBindingData returns the host, and the path when informed, of the informed route.
+func routeBindingData(obj *unstructured.Unstructured) (map[string][]byte, error) {
+	result := map[string][]byte{}
+	for _, key := range []string{"host", "path"} {
+		value, found, err := unstructured.NestedString(obj.Object, "spec", key)
+		if err != nil {
+			return nil, err
+		}
+		if found && value != "" {
+			result[key] = []byte(value)
+		}
+	}
+	return result, nil
+}
+
+// ReadCoreBackingServiceData reads binding data from the informed backing service of a core kind,
+// using its built-in extraction rules.
+func (r *Retriever) ReadCoreBackingServiceData(obj *unstructured.Unstructured) error {
+	extract, ok := coreBackingServices[obj.GroupVersionKind().GroupKind()]
+	if !ok {
+		return fmt.Errorf("%w: %s", UnknownKindErr, obj.GroupVersionKind())
+	}
+	r.logger.Debug("Reading core backing service data...", "Kind", obj.GetKind(), "Name", obj.GetName())
+	data, err := extract(obj)
+	if err != nil {
+		return err
+	}
+	for k, v := range data {
+		r.store(obj, k, v)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package servicebindingrequest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/converter"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestCoreBindingData(t *testing.T) {
+	ns := "core"
+
+	t.Run("secret", func(t *testing.T) {
+		u, err := converter.ToUnstructured(mocks.SecretMock(ns, "db"))
+		require.NoError(t, err)
+		data, err := secretBindingData(u)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]byte{"user": []byte("user"), "password": []byte("password")}, data)
+	})
+
+	t.Run("config map", func(t *testing.T) {
+		u, err := converter.ToUnstructured(mocks.ConfigMapMock(ns, "db"))
+		require.NoError(t, err)
+		data, err := configMapBindingData(u)
+		require.NoError(t, err)
+		require.NotEmpty(t, data)
+	})
+
+	t.Run("service", func(t *testing.T) {
+		u, err := converter.ToUnstructured(mocks.ServiceMock(ns, "db"))
+		require.NoError(t, err)
+		data, err := serviceBindingData(u)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]byte{
+			"host":            []byte("db.core.svc"),
+			"clusterIP":       []byte("172.30.0.10"),
+			"port":            []byte("5432"),
+			"port_postgresql": []byte("5432"),
+			"port_metrics":    []byte("9187"),
+		}, data)
+	})
+
+	t.Run("route", func(t *testing.T) {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(mocks.RouteCRMock(ns, "db"))
+		require.NoError(t, err)
+		data, err := routeBindingData(&unstructured.Unstructured{Object: obj})
+		require.NoError(t, err)
+		require.Equal(t, map[string][]byte{"host": []byte("https://openshift.cluster.com/host_url")}, data)
+	})
+}
+
+// TestReconcileCoreBackingServices checks Services and Secrets are bound without CRDs or CSVs
+// describing them.
+func TestReconcileCoreBackingServices(t *testing.T) {
+	f := mocks.NewFake(t, reconcilerNs)
+	sbr := f.AddMockedServiceBindingRequest(reconcilerName, nil, "", reconcilerName, deploymentsGVR, nil)
+	sbr.Spec.BackingServiceSelector = nil
+	sbr.Spec.CustomEnvVar = nil
+	sbr.Spec.BackingServiceSelectors = &[]v1alpha1.BackingServiceSelector{
+		{GroupVersionKind: metav1.GroupVersionKind{Version: "v1", Kind: "Service"}, ResourceRef: "db"},
+		{GroupVersionKind: metav1.GroupVersionKind{Version: "v1", Kind: "Secret"}, ResourceRef: "db-credentials"},
+	}
+	f.AddMockResource(mocks.ServiceMock(reconcilerNs, "db"))
+	f.AddMockedSecret("db-credentials")
+	f.AddMockedUnstructuredDeployment(reconcilerName, nil)
+
+	fakeDynClient := f.FakeDynClient()
+	reconciler := &Reconciler{
+		client:     f.FakeClient(),
+		dynClient:  fakeDynClient,
+		restMapper: f.FakeRESTMapper(),
+		scheme:     f.S,
+	}
+
+	res, err := reconciler.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	sbrOutput, err := reconciler.getServiceBindingRequest(namespacedName)
+	require.NoError(t, err)
+	require.Equal(t, BindingSuccess, sbrOutput.Status.BindingStatus)
+
+	secret, err := fakeDynClient.Resource(corev1.SchemeGroupVersion.WithResource(SecretResource)).
+		Namespace(reconcilerNs).
+		Get(sbrOutput.Status.Secret, metav1.GetOptions{})
+	require.NoError(t, err)
+	data := secret.Object["data"].(map[string]interface{})
+	for _, key := range []string{"SERVICE_HOST", "SERVICE_PORT", "SERVICE_CLUSTERIP", "SECRET_USER", "SECRET_PASSWORD"} {
+		require.Contains(t, data, key)
+	}
+}
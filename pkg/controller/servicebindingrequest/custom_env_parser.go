@@ -21,11 +21,16 @@ func NewCustomEnvParser(envMap []corev1.EnvVar, cache map[string]interface{}) *C
 	}
 }
 
+// parseCustomEnvTemplate parses the informed custom environment value as a template.
+func parseCustomEnvTemplate(value string) (*template.Template, error) {
+	return template.New("set").Parse(value)
+}
+
 // Parse interpolates and caches the templates in EnvMap.
 func (c *CustomEnvParser) Parse() (map[string]interface{}, error) {
 	data := make(map[string]interface{})
 	for _, v := range c.EnvMap {
-		tmpl, err := template.New("set").Parse(v.Value)
+		tmpl, err := parseCustomEnvTemplate(v.Value)
 		if err != nil {
 			return data, err
 		}
@@ -0,0 +1,175 @@
+package servicebindingrequest
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// BindingDrift is the event reason used when bound applications are found missing binding items.
+const BindingDrift = "BindingDrift"
+
+// missingInjections returns a description of each item recorded in injected that is not found in
+// the informed workload anymore, where key is the namespaced name of the service binding request.
+// Containers not present anymore are not taken into account.
+func missingInjections(obj *unstructured.Unstructured, key string, injected *injections) ([]string, error) {
+	missing := []string{}
+
+	adapter := injected.adapter(obj)
+	containers, _, err := unstructured.NestedSlice(obj.Object, adapter.ContainersPath()...)
+	if err != nil {
+		return nil, err
+	}
+	for idx, container := range containers {
+		containerKey := containerKey(idx, container)
+		recorded, ok := injected.Containers[containerKey]
+		if !ok {
+			continue
+		}
+		u, ok := container.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		c := &corev1.Container{}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(u, c); err != nil {
+			return nil, err
+		}
+
+		for _, name := range recorded.Env {
+			if !hasEnvVar(c.Env, name) {
+				missing = append(missing, fmt.Sprintf("container '%s' env '%s'", containerKey, name))
+			}
+		}
+		for _, name := range recorded.EnvFrom {
+			if !hasEnvFromSecret(c.EnvFrom, name) {
+				missing = append(missing, fmt.Sprintf("container '%s' envFrom secret '%s'", containerKey, name))
+			}
+		}
+		for _, name := range recorded.VolumeMounts {
+			if !hasVolumeMount(c.VolumeMounts, name) {
+				missing = append(missing, fmt.Sprintf("container '%s' volumeMount '%s'", containerKey, name))
+			}
+		}
+	}
+
+	volumes, _, err := unstructured.NestedSlice(obj.Object, adapter.VolumesPath()...)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range injected.Volumes {
+		found := false
+		for _, v := range volumes {
+			if volumeName(v) == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, fmt.Sprintf("volume '%s'", name))
+		}
+	}
+
+	if injected.BindingHash {
+		hashes, _, err := readBindingHashes(obj, adapter.PodAnnotationsPath())
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := hashes[key]; !ok {
+			missing = append(missing, fmt.Sprintf("pod template annotation '%s'", bindingHashAnnotation))
+		}
+	}
+
+	return missing, nil
+}
+
+// hasDrifted returns whether the items recorded as injected in the old workload are missing in the
+// new one, for any service binding request, including the case where the record itself is removed.
+func hasDrifted(objOld, objNew runtime.Object) (bool, error) {
+	mapOld, err := runtime.DefaultUnstructuredConverter.ToUnstructured(objOld)
+	if err != nil {
+		return false, err
+	}
+	mapNew, err := runtime.DefaultUnstructuredConverter.ToUnstructured(objNew)
+	if err != nil {
+		return false, err
+	}
+	uOld := &unstructured.Unstructured{Object: mapOld}
+	uNew := &unstructured.Unstructured{Object: mapNew}
+
+	recordedOld, err := readAllInjections(uOld)
+	if err != nil {
+		return false, err
+	}
+	recordedNew, err := readAllInjections(uNew)
+	if err != nil {
+		return false, err
+	}
+
+	for key, injected := range recordedOld {
+		if i, ok := recordedNew[key]; ok {
+			injected = i
+		}
+		if injected == nil {
+			continue
+		}
+		missing, err := missingInjections(uNew, key, injected)
+		if err != nil {
+			return false, err
+		}
+		if len(missing) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isBound returns whether the informed object is recorded as bound in the SBR status, applications
+// recorded without a namespace being in the SBR namespace.
+func (b *Binder) isBound(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	for _, app := range b.sbr.Status.ApplicationObjects {
+		ns := app.Namespace
+		if ns == "" {
+			ns = b.sbr.GetNamespace()
+		}
+		if app.Group == gvk.Group && app.Kind == gvk.Kind && app.Name == obj.GetName() &&
+			ns == obj.GetNamespace() {
+			return true
+		}
+	}
+	return false
+}
+
+// missingInjections returns a description of each binding item missing in the informed object,
+// when it was previously bound by the SBR. When the record of injected items has been removed, the
+// intermediary secret is expected to be referred by at least one container.
+func (b *Binder) missingInjections(
+	obj *unstructured.Unstructured,
+	injected *injections,
+	found bool,
+) ([]string, error) {
+	if found {
+		return missingInjections(obj, b.namespacedName().String(), injected)
+	}
+	if !b.isBound(obj) {
+		return nil, nil
+	}
+
+	name := b.sbr.GetName()
+	containers, err := b.extractSpecContainers(obj, workloadAdapterFor(obj, b.bindingPath(obj)))
+	if err != nil {
+		return nil, err
+	}
+	for _, container := range containers {
+		c, err := b.containerFromUnstructured(container)
+		if err != nil {
+			return nil, err
+		}
+		if hasEnvFromSecret(c.EnvFrom, name) {
+			return nil, nil
+		}
+	}
+	return []string{fmt.Sprintf("envFrom secret '%s'", name)}, nil
+}
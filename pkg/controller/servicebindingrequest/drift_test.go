@@ -0,0 +1,160 @@
+package servicebindingrequest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestBinderDrift(t *testing.T) {
+	ns := "drift"
+	name := "service-binding-request"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "drift",
+	}
+
+	f := mocks.NewFake(t, ns)
+	obj := f.AddMockedUnstructuredDeployment("app", matchLabels)
+	sbr := mocks.ServiceBindingRequestMock(ns, name, nil, "ref", "", deploymentsGVR, matchLabels)
+	binder := NewBinder(
+		context.TODO(),
+		f.FakeClient(),
+		f.FakeDynClient(),
+		f.FakeRESTMapper(),
+		sbr,
+		[]VolumeKey{{Key: "DATABASE_SECRET_PASSWORD", Path: "DATABASE_SECRET_PASSWORD"}},
+	)
+
+	// bind binds the informed object, returning the bound object.
+	bind := func(t *testing.T, obj *unstructured.Unstructured) *unstructured.Unstructured {
+		updatedObjects, err := binder.update(&unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{*obj.DeepCopy()},
+		})
+		require.NoError(t, err)
+		require.Len(t, updatedObjects, 1)
+		return updatedObjects[0]
+	}
+
+	// removeEnvFrom returns a copy of the informed object without envFrom in its containers.
+	removeEnvFrom := func(t *testing.T, obj *unstructured.Unstructured) *unstructured.Unstructured {
+		u := obj.DeepCopy()
+		containers, _, err := unstructured.NestedSlice(u.Object, containersPath...)
+		require.NoError(t, err)
+		for _, c := range containers {
+			unstructured.RemoveNestedField(c.(map[string]interface{}), "envFrom")
+		}
+		require.NoError(t, unstructured.SetNestedSlice(u.Object, containers, containersPath...))
+		return u
+	}
+
+	bound := bind(t, obj)
+	require.Empty(t, binder.drifts)
+	sbr.Status.ApplicationObjects = []v1alpha1.BoundApplication{{
+		GroupVersionKind:     metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		LocalObjectReference: corev1.LocalObjectReference{Name: "app"},
+	}}
+
+	t.Run("missing injections", func(t *testing.T) {
+		injected, found, err := readInjections(bound, binder.namespacedName())
+		require.NoError(t, err)
+		require.True(t, found)
+
+		missing, err := missingInjections(bound, binder.namespacedName().String(), injected)
+		require.NoError(t, err)
+		require.Empty(t, missing)
+
+		missing, err = missingInjections(removeEnvFrom(t, bound), binder.namespacedName().String(), injected)
+		require.NoError(t, err)
+		require.Equal(t, []string{"container 'busybox' envFrom secret 'service-binding-request'"}, missing)
+
+		unmounted := bound.DeepCopy()
+		unstructured.RemoveNestedField(unmounted.Object, volumesPath...)
+		unstructured.RemoveNestedField(unmounted.Object, podAnnotationsPath...)
+		missing, err = missingInjections(unmounted, binder.namespacedName().String(), injected)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{
+			"volume 'service-binding-request'",
+			"pod template annotation '" + bindingHashAnnotation + "'",
+		}, missing)
+	})
+
+	t.Run("has drifted", func(t *testing.T) {
+		drifted, err := hasDrifted(bound, bound)
+		require.NoError(t, err)
+		require.False(t, drifted)
+
+		// binding items are added to an unbound object
+		drifted, err = hasDrifted(obj, bound)
+		require.NoError(t, err)
+		require.False(t, drifted)
+
+		drifted, err = hasDrifted(bound, removeEnvFrom(t, bound))
+		require.NoError(t, err)
+		require.True(t, drifted)
+
+		// record is removed, while binding items are still present
+		unrecorded := bound.DeepCopy()
+		unrecorded.SetAnnotations(nil)
+		drifted, err = hasDrifted(bound, unrecorded)
+		require.NoError(t, err)
+		require.False(t, drifted)
+	})
+
+	t.Run("re-applied", func(t *testing.T) {
+		rebound := bind(t, removeEnvFrom(t, bound))
+		require.Len(t, binder.drifts, 1)
+		require.Contains(t, binder.drifts[0], "Deployment 'app' is missing")
+		require.Equal(t, bound.Object["spec"], rebound.Object["spec"])
+
+		// record removed along with the binding items
+		unrecorded := removeEnvFrom(t, bound)
+		unrecorded.SetAnnotations(nil)
+		rebound = bind(t, unrecorded)
+		require.Equal(t, []string{"Deployment 'app' is missing envFrom secret 'service-binding-request'"}, binder.drifts)
+		require.Equal(t, bound.Object["spec"], rebound.Object["spec"])
+
+		bind(t, rebound)
+		require.Empty(t, binder.drifts)
+	})
+
+	t.Run("same name in other namespace", func(t *testing.T) {
+		other := removeEnvFrom(t, obj)
+		other.SetNamespace("other")
+		require.True(t, binder.isBound(obj))
+		require.False(t, binder.isBound(other))
+		missing, err := binder.missingInjections(other, nil, false)
+		require.NoError(t, err)
+		require.Empty(t, missing)
+
+		// applications bound in other namespaces are recorded along with their namespace
+		recorded := sbr.Status.ApplicationObjects
+		defer func() { sbr.Status.ApplicationObjects = recorded }()
+		sbr.Status.ApplicationObjects = []v1alpha1.BoundApplication{{
+			GroupVersionKind:     metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			LocalObjectReference: corev1.LocalObjectReference{Name: "app"},
+			Namespace:            "other",
+		}}
+		require.False(t, binder.isBound(obj))
+		require.True(t, binder.isBound(other))
+	})
+
+	t.Run("recorded", func(t *testing.T) {
+		bind(t, removeEnvFrom(t, bound))
+		recorder := record.NewFakeRecorder(1)
+		sb := &ServiceBinder{Binder: binder, SBR: sbr, Recorder: recorder}
+
+		sbrStatus := &v1alpha1.ServiceBindingRequestStatus{DriftCount: 1}
+		sb.recordDrifts(sbrStatus)
+		require.Equal(t, int64(2), sbrStatus.DriftCount)
+		require.Contains(t, <-recorder.Events, BindingDrift)
+	})
+}
@@ -0,0 +1,63 @@
+package servicebindingrequest
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ServiceBindingRootEnv is the environment variable informing applications the directory
+	// bindings are projected into as files, following the servicebinding.io directory layout.
+	ServiceBindingRootEnv = "SERVICE_BINDING_ROOT"
+	// defaultServiceBindingRoot is the directory bindings are projected into, when not informed.
+	defaultServiceBindingRoot = "/bindings"
+	// bindingTypeKey is the binding entry informing the type of the backing service.
+	bindingTypeKey = "type"
+	// bindingProviderKey is the binding entry informing the provider of the backing service.
+	bindingProviderKey = "provider"
+)
+
+// addBindingFileEntries adds the "type" and "provider" entries, required by servicebinding.io
+// binding libraries, to the informed binding data, unless already present. Those are taken from
+// the SBR when informed, otherwise from the first backing service in the plan.
+func addBindingFileEntries(data map[string][]byte, plan *Plan) {
+	bindingType := plan.SBR.Spec.Type
+	provider := plan.SBR.Spec.Provider
+	if crs := plan.GetCRs(); len(crs) > 0 {
+		gvk := crs[0].GroupVersionKind()
+		if bindingType == "" {
+			bindingType = strings.ToLower(gvk.Kind)
+		}
+		if provider == "" {
+			provider = gvk.Group
+		}
+	}
+
+	if _, ok := data[bindingTypeKey]; !ok && bindingType != "" {
+		data[bindingTypeKey] = []byte(bindingType)
+	}
+	if _, ok := data[bindingProviderKey]; !ok && provider != "" {
+		data[bindingProviderKey] = []byte(provider)
+	}
+}
+
+// serviceBindingRoot returns the directory bindings are projected into as files for a container
+// having the informed environment, honoring SERVICE_BINDING_ROOT when already declared.
+func (b *Binder) serviceBindingRoot(envList []corev1.EnvVar) string {
+	for _, env := range envList {
+		if env.Name == ServiceBindingRootEnv && env.Value != "" {
+			return env.Value
+		}
+	}
+	if b.sbr.Spec.MountPathPrefix != "" {
+		return b.sbr.Spec.MountPathPrefix
+	}
+	return defaultServiceBindingRoot
+}
+
+// bindingFilesPath returns the directory the binding is projected into as files, in a container
+// having the informed environment.
+func (b *Binder) bindingFilesPath(envList []corev1.EnvVar) string {
+	return strings.TrimSuffix(b.serviceBindingRoot(envList), "/") + "/" + b.sbr.GetName()
+}
@@ -0,0 +1,216 @@
+package servicebindingrequest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestAddBindingFileEntries(t *testing.T) {
+	ns := "files"
+	cr, err := mocks.UnstructuredDatabaseCRMock(ns, "db")
+	require.NoError(t, err)
+	sbr := mocks.ServiceBindingRequestMock(ns, "sbr", nil, "db", "", deploymentsGVR, nil)
+	plan := &Plan{Ns: ns, Name: "sbr", SBR: *sbr, RelatedResources: RelatedResources{{CR: cr}}}
+
+	t.Run("from backing service", func(t *testing.T) {
+		data := map[string][]byte{"password": []byte("secret")}
+		addBindingFileEntries(data, plan)
+		require.Equal(t, map[string][]byte{
+			"password": []byte("secret"),
+			"type":     []byte("database"),
+			"provider": []byte(cr.GroupVersionKind().Group),
+		}, data)
+	})
+
+	t.Run("from sbr", func(t *testing.T) {
+		p := *plan
+		p.SBR.Spec.Type = "postgresql"
+		p.SBR.Spec.Provider = "baiju"
+		data := map[string][]byte{}
+		addBindingFileEntries(data, &p)
+		require.Equal(t, "postgresql", string(data["type"]))
+		require.Equal(t, "baiju", string(data["provider"]))
+	})
+
+	t.Run("present in data", func(t *testing.T) {
+		data := map[string][]byte{"type": []byte("mysql")}
+		addBindingFileEntries(data, plan)
+		require.Equal(t, "mysql", string(data["type"]))
+	})
+}
+
+func TestBinderBindAsFiles(t *testing.T) {
+	ns := "files"
+	name := "sbr"
+	matchLabels := map[string]string{"connects-to": "database"}
+
+	podSpec := func(t *testing.T, obj *unstructured.Unstructured) *corev1.PodSpec {
+		spec, found, err := unstructured.NestedMap(obj.Object, "spec", "template", "spec")
+		require.NoError(t, err)
+		require.True(t, found)
+		podSpec := &corev1.PodSpec{}
+		require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(spec, podSpec))
+		return podSpec
+	}
+
+	// bind binds the SBR against the informed workload, returning the bound workload
+	bind := func(
+		t *testing.T,
+		f *mocks.Fake,
+		sbr *v1alpha1.ServiceBindingRequest,
+		obj *unstructured.Unstructured,
+	) *unstructured.Unstructured {
+		binder := NewBinder(
+			context.TODO(), f.FakeClient(), f.FakeDynClient(), f.FakeRESTMapper(), sbr, []VolumeKey{})
+		updatedObjects, err := binder.update(&unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{*obj.DeepCopy()},
+		})
+		require.NoError(t, err)
+		require.Len(t, updatedObjects, 1)
+		return updatedObjects[0]
+	}
+
+	t.Run("default root", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		obj := f.AddMockedUnstructuredDeployment("app", matchLabels)
+		sbr := f.AddMockedServiceBindingRequest(name, nil, "ref", "", deploymentsGVR, matchLabels)
+		sbr.Spec.BindAsFiles = true
+		sbr.Spec.MountPathPrefix = ""
+
+		bound := bind(t, f, sbr, obj)
+		spec := podSpec(t, bound)
+		c := spec.Containers[0]
+		require.Empty(t, c.EnvFrom)
+		require.Equal(t, []corev1.EnvVar{{Name: ServiceBindingRootEnv, Value: "/bindings"}}, c.Env)
+		require.Equal(t, []corev1.VolumeMount{{Name: name, MountPath: "/bindings/sbr"}}, c.VolumeMounts)
+		require.Len(t, spec.Volumes, 1)
+		require.Equal(t, name, spec.Volumes[0].Secret.SecretName)
+		require.Empty(t, spec.Volumes[0].Secret.Items)
+
+		// switching back to environment variables removes what was injected for files
+		sbr.Spec.BindAsFiles = false
+		rebound := bind(t, f, sbr, bound)
+		spec = podSpec(t, rebound)
+		c = spec.Containers[0]
+		require.Len(t, c.EnvFrom, 1)
+		require.Equal(t, name, c.EnvFrom[0].SecretRef.Name)
+		require.Empty(t, c.Env)
+		require.Empty(t, c.VolumeMounts)
+		require.Empty(t, spec.Volumes)
+
+		injected, found, err := readInjections(rebound, types.NamespacedName{Namespace: ns, Name: name})
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Empty(t, injected.Volumes)
+		require.Equal(t, &containerInjections{EnvFrom: []string{name}}, injected.Containers["busybox"])
+	})
+
+	t.Run("mount path prefix", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		obj := f.AddMockedUnstructuredDeployment("app", matchLabels)
+		sbr := f.AddMockedServiceBindingRequest(name, nil, "ref", "", deploymentsGVR, matchLabels)
+		sbr.Spec.BindAsFiles = true
+		sbr.Spec.MountPathPrefix = "/platform/bindings/"
+
+		c := podSpec(t, bind(t, f, sbr, obj)).Containers[0]
+		require.Equal(t, "/platform/bindings/", getEnvVar(c.Env, ServiceBindingRootEnv).Value)
+		require.Equal(t, "/platform/bindings/sbr", c.VolumeMounts[0].MountPath)
+	})
+
+	t.Run("root declared by the container", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		obj := f.AddMockedUnstructuredDeployment("app", matchLabels)
+		containers, _, err := unstructured.NestedSlice(obj.Object, containersPath...)
+		require.NoError(t, err)
+		containers[0].(map[string]interface{})["env"] = []interface{}{
+			map[string]interface{}{"name": ServiceBindingRootEnv, "value": "/custom"},
+		}
+		require.NoError(t, unstructured.SetNestedSlice(obj.Object, containers, containersPath...))
+		sbr := f.AddMockedServiceBindingRequest(name, nil, "ref", "", deploymentsGVR, matchLabels)
+		sbr.Spec.BindAsFiles = true
+
+		bound := bind(t, f, sbr, obj)
+		c := podSpec(t, bound).Containers[0]
+		require.Equal(t, []corev1.EnvVar{{Name: ServiceBindingRootEnv, Value: "/custom"}}, c.Env)
+		require.Equal(t, "/custom/sbr", c.VolumeMounts[0].MountPath)
+
+		// the environment variable is not recorded, so it's kept when unbinding
+		injected, _, err := readInjections(bound, types.NamespacedName{Namespace: ns, Name: name})
+		require.NoError(t, err)
+		require.Empty(t, injected.Containers["busybox"].Env)
+	})
+}
+
+// TestBindAsFilesKeys checks the binding data is projected as files named after the keys as they
+// are, which binding libraries look for.
+func TestBindAsFilesKeys(t *testing.T) {
+	ns := "files"
+	name := "sbr"
+
+	// keys returns the keys of the informed data, the names of the projected files
+	keys := func(data map[string][]byte) []string {
+		result := []string{}
+		for k := range data {
+			result = append(result, k)
+		}
+		return result
+	}
+
+	t.Run("described backing service", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		f.AddMockedSecret("db-credentials")
+		cr, err := mocks.UnstructuredDatabaseCRMock(ns, "db")
+		require.NoError(t, err)
+		sbr := mocks.ServiceBindingRequestMock(ns, name, nil, "db", "", deploymentsGVR, nil)
+		sbr.Spec.BindAsFiles = true
+		sbr.Spec.CustomEnvVar = nil
+		plan := &Plan{Ns: ns, Name: name, SBR: *sbr, RelatedResources: RelatedResources{{CR: cr}}}
+
+		retriever := NewRetriever(f.FakeDynClient(), plan, "SERVICE_BINDING")
+		require.NoError(t, retriever.read(cr, "status", "dbCredentials", []string{
+			"binding:env:object:secret:user",
+			"binding:env:object:secret:password",
+		}))
+		require.NoError(t, retriever.read(cr, "spec", "image", []string{"binding:env:attribute"}))
+		data, err := retriever.Get()
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"user", "password", "image"}, keys(data))
+	})
+
+	t.Run("core backing services", func(t *testing.T) {
+		f := mocks.NewFake(t, ns)
+		sbr := f.AddMockedServiceBindingRequest(name, nil, "", "app", deploymentsGVR, nil)
+		sbr.Spec.BackingServiceSelector = nil
+		sbr.Spec.CustomEnvVar = nil
+		sbr.Spec.BindAsFiles = true
+		sbr.Spec.BackingServiceSelectors = &[]v1alpha1.BackingServiceSelector{
+			{GroupVersionKind: metav1.GroupVersionKind{Version: "v1", Kind: "Service"}, ResourceRef: "db"},
+			{GroupVersionKind: metav1.GroupVersionKind{Version: "v1", Kind: "Secret"}, ResourceRef: "db-credentials"},
+		}
+		f.AddMockResource(mocks.ServiceMock(ns, "db"))
+		f.AddMockedSecret("db-credentials")
+
+		sb, err := BuildServiceBinder(&ServiceBinderOptions{
+			Client:       f.FakeClient(),
+			DynClient:    f.FakeDynClient(),
+			RESTMapper:   f.FakeRESTMapper(),
+			SBR:          sbr,
+			EnvVarPrefix: "SERVICE_BINDING",
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{
+			"host", "clusterIP", "port", "port_postgresql", "port_metrics",
+			"user", "password", "type",
+		}, keys(sb.Data))
+	})
+}
@@ -0,0 +1,212 @@
+package servicebindingrequest
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+)
+
+// injectionsAnnotation is the workload annotation recording, per service binding request, the
+// items the operator has injected on it, so only those are removed when unbinding.
+const injectionsAnnotation = "service-binding-operator.apps.openshift.io/injections"
+
+// containerInjections are the items injected in a single container.
+type containerInjections struct {
+	// Env are the names of environment variables.
+	Env []string `json:"env,omitempty"`
+	// EnvFrom are the names of secrets referred by envFrom entries.
+	EnvFrom []string `json:"envFrom,omitempty"`
+	// VolumeMounts are the names of volume mounts.
+	VolumeMounts []string `json:"volumeMounts,omitempty"`
+}
+
+// injections are the items injected by a service binding request in a workload.
+type injections struct {
+	// Containers are the items injected per container, keyed by containerKey.
+	Containers map[string]*containerInjections `json:"containers,omitempty"`
+	// Volumes are the names of volumes injected in the pod spec.
+	Volumes []string `json:"volumes,omitempty"`
+	// BindingHash is whether the binding hash is set in the pod template annotations.
+	BindingHash bool `json:"bindingHash,omitempty"`
+	// BindingPath is the binding path declared by the service binding request when items were
+	// injected, locating them in workloads not handled by the built-in adapters.
+	BindingPath *v1alpha1.BindingPath `json:"bindingPath,omitempty"`
+}
+
+// adapter returns the adapter locating the injected items in the informed workload.
+func (i *injections) adapter(obj *unstructured.Unstructured) WorkloadAdapter {
+	return workloadAdapterFor(obj, i.BindingPath)
+}
+
+// newInjections returns an empty injections record.
+func newInjections() *injections {
+	return &injections{Containers: make(map[string]*containerInjections)}
+}
+
+// legacyInjections returns the items injected in the informed containers by operator versions not
+// recording injections, which are all named after the service binding request.
+func legacyInjections(name string, containers []interface{}) *injections {
+	i := newInjections()
+	for idx, container := range containers {
+		i.Containers[containerKey(idx, container)] = &containerInjections{
+			Env:          []string{ChangeTriggerEnv},
+			EnvFrom:      []string{name},
+			VolumeMounts: []string{name},
+		}
+	}
+	i.Volumes = []string{name}
+	return i
+}
+
+// container returns the items injected in the container identified by key, creating an empty
+// entry when it's not yet recorded.
+func (i *injections) container(key string) *containerInjections {
+	c, ok := i.Containers[key]
+	if !ok {
+		c = &containerInjections{}
+		i.Containers[key] = c
+	}
+	return c
+}
+
+// isEmpty returns whether no items are recorded.
+func (i *injections) isEmpty() bool {
+	if len(i.Volumes) > 0 || i.BindingHash {
+		return false
+	}
+	for _, c := range i.Containers {
+		if len(c.Env) > 0 || len(c.EnvFrom) > 0 || len(c.VolumeMounts) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// containerKey identifies the informed unstructured container by its name, falling back to its
+// position when unnamed, as may happen with Knative Services.
+func containerKey(idx int, container interface{}) string {
+	if u, ok := container.(map[string]interface{}); ok {
+		if name, _, _ := unstructured.NestedString(u, "name"); name != "" {
+			return name
+		}
+	}
+	return fmt.Sprintf("[%d]", idx)
+}
+
+// appendInjected appends value to the recorded items in case it was injected, in other words, when
+// it was not present before injection or it was already recorded.
+func appendInjected(recorded []string, value string, presentBefore bool) []string {
+	if presentBefore || containsStringSlice(recorded, value) {
+		return recorded
+	}
+	return append(recorded, value)
+}
+
+// readAllInjections returns all the injections recorded in the informed workload, keyed by
+// service binding request namespaced name.
+func readAllInjections(obj *unstructured.Unstructured) (map[string]*injections, error) {
+	all := make(map[string]*injections)
+	value, ok := obj.GetAnnotations()[injectionsAnnotation]
+	if !ok || value == "" {
+		return all, nil
+	}
+	if err := json.Unmarshal([]byte(value), &all); err != nil {
+		return nil, fmt.Errorf("unable to parse annotation '%s': %s", injectionsAnnotation, err)
+	}
+	return all, nil
+}
+
+// readInjections returns the injections recorded in the informed workload for the service binding
+// request, and whether those were found.
+func readInjections(
+	obj *unstructured.Unstructured,
+	namespacedName types.NamespacedName,
+) (*injections, bool, error) {
+	all, err := readAllInjections(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	i, ok := all[namespacedName.String()]
+	if !ok || i == nil {
+		return newInjections(), false, nil
+	}
+	if i.Containers == nil {
+		i.Containers = make(map[string]*containerInjections)
+	}
+	return i, true, nil
+}
+
+// writeInjections records the injections of the service binding request in the informed workload,
+// removing its entry when nothing is injected, and the annotation itself when no entries are left.
+func writeInjections(
+	obj *unstructured.Unstructured,
+	namespacedName types.NamespacedName,
+	i *injections,
+) error {
+	all, err := readAllInjections(obj)
+	if err != nil {
+		return err
+	}
+	if i == nil || i.isEmpty() {
+		delete(all, namespacedName.String())
+	} else {
+		all[namespacedName.String()] = i
+	}
+
+	annotations := obj.GetAnnotations()
+	if len(all) == 0 {
+		delete(annotations, injectionsAnnotation)
+		if len(annotations) == 0 {
+			// not leaving an empty map behind, since pod annotations may be the workload ones
+			annotations = nil
+		}
+		obj.SetAnnotations(annotations)
+		return nil
+	}
+
+	data, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[injectionsAnnotation] = string(data)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// hasEnvVar returns whether an environment variable with the informed name is present.
+func hasEnvVar(envList []corev1.EnvVar, name string) bool {
+	for _, env := range envList {
+		if env.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasEnvFromSecret returns whether an envFrom entry referring to the informed secret is present.
+func hasEnvFromSecret(envList []corev1.EnvFromSource, secret string) bool {
+	for _, env := range envList {
+		if env.SecretRef != nil && env.SecretRef.Name == secret {
+			return true
+		}
+	}
+	return false
+}
+
+// hasVolumeMount returns whether a volume mount with the informed name is present.
+func hasVolumeMount(volumeMounts []corev1.VolumeMount, name string) bool {
+	for _, v := range volumeMounts {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
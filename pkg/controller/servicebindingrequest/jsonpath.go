@@ -0,0 +1,143 @@
+package servicebindingrequest
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// InvalidPathErr is returned when a descriptor path is not a valid JSONPath, or selects a value of
+// an unexpected type.
+var InvalidPathErr = errors.New("invalid descriptor path")
+
+var (
+	// numericSegmentRegexp matches dotted path segments which are indexes, as in "endpoints.0.host".
+	numericSegmentRegexp = regexp.MustCompile(`^[0-9]+$`)
+	// pathKeyRegexp matches characters not allowed in keys derived from descriptor paths.
+	pathKeyRegexp = regexp.MustCompile(`[^A-Za-z0-9_\-]+`)
+)
+
+// descriptorJSONPath returns the JSONPath template of the informed descriptor path. Paths may be
+// JSONPath templates, as in "{.endpoints[?(@.name=="primary")].host}", JSONPath expressions
+// without braces, as in "endpoints[*].host", or dotted paths, where numeric segments are indexes,
+// as in "endpoints.0.host", since annotation names can't have brackets.
+func descriptorJSONPath(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+
+	var b strings.Builder
+	depth := 0
+	segment := 0
+	for i := 0; i <= len(path); i++ {
+		if i < len(path) && (path[i] != '.' || depth > 0) {
+			switch path[i] {
+			case '[':
+				depth++
+			case ']':
+				depth--
+			}
+			continue
+		}
+		s := path[segment:i]
+		if depth == 0 && numericSegmentRegexp.MatchString(s) {
+			fmt.Fprintf(&b, "[%s]", s)
+		} else {
+			fmt.Fprintf(&b, ".%s", s)
+		}
+		segment = i + 1
+	}
+	return "{" + b.String() + "}"
+}
+
+// leadingPathFields returns the plain fields a descriptor path starts with, before any index,
+// filter or wildcard.
+func leadingPathFields(path string) []string {
+	path = strings.TrimLeft(path, "{$.")
+	if i := strings.IndexAny(path, "[]{}*?@$"); i >= 0 {
+		path = path[:i]
+	}
+	var fields []string
+	for _, f := range strings.Split(path, ".") {
+		if f == "" || numericSegmentRegexp.MatchString(f) {
+			break
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// pathKey returns the key the value of the informed descriptor path is stored as, made only of
+// characters allowed in environment variable names and dots, which are replaced when stored.
+func pathKey(path string) string {
+	path = strings.Trim(path, "{}$.")
+	return strings.Trim(pathKeyRegexp.ReplaceAllString(path, "_"), "_")
+}
+
+// findPathValues returns the values selected by the informed descriptor path in data. Missing
+// fields select no value, while type mismatches, such as indexing a map, are errors.
+func findPathValues(data interface{}, path string) ([]interface{}, error) {
+	jp := jsonpath.New(path).AllowMissingKeys(true)
+	if err := jp.Parse(descriptorJSONPath(path)); err != nil {
+		return nil, fmt.Errorf("%w: '%s': %s", InvalidPathErr, path, err)
+	}
+	results, err := jp.FindResults(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: '%s': %s", InvalidPathErr, path, err)
+	}
+	var values []interface{}
+	for _, r := range results {
+		for _, v := range r {
+			if v.IsValid() && v.CanInterface() {
+				values = append(values, v.Interface())
+			}
+		}
+	}
+	return values, nil
+}
+
+// flattenValue adds the informed value to acc under the informed key when it's a scalar. Maps and
+// slices are flattened into a key per entry, suffixed by the entry key or index.
+func flattenValue(acc map[string]string, key string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			flattenValue(acc, key+"_"+k, v[k])
+		}
+	case []interface{}:
+		for i, e := range v {
+			flattenValue(acc, key+"_"+strconv.Itoa(i), e)
+		}
+	case nil:
+		acc[key] = ""
+	default:
+		acc[key] = fmt.Sprintf("%v", v)
+	}
+}
+
+// flattenValues flattens the values selected by a descriptor path under the informed key, a
+// single value being flattened as is, and multiple values as a slice.
+func flattenValues(key string, values []interface{}) map[string]string {
+	acc := make(map[string]string)
+	switch len(values) {
+	case 0:
+		acc[key] = ""
+	case 1:
+		flattenValue(acc, key, values[0])
+	default:
+		flattenValue(acc, key, values)
+	}
+	return acc
+}
@@ -0,0 +1,94 @@
+package servicebindingrequest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescriptorJSONPath(t *testing.T) {
+	tests := map[string]string{
+		"dbCredentials":                           "{.dbCredentials}",
+		"image.name":                              "{.image.name}",
+		"endpoints.0.host":                        "{.endpoints[0].host}",
+		"endpoints[*].host":                       "{.endpoints[*].host}",
+		"$.endpoints[1]":                          "{.endpoints[1]}",
+		`endpoints[?(@.weight==1.5)].ports.0`:     `{.endpoints[?(@.weight==1.5)].ports[0]}`,
+		`{.endpoints[?(@.name=="primary")].host}`: `{.endpoints[?(@.name=="primary")].host}`,
+	}
+	for path, expected := range tests {
+		require.Equal(t, expected, descriptorJSONPath(path), path)
+	}
+}
+
+func TestLeadingPathFields(t *testing.T) {
+	require.Equal(t, []string{"dbCredentials"}, leadingPathFields("dbCredentials"))
+	require.Equal(t, []string{"image", "name"}, leadingPathFields("image.name"))
+	require.Equal(t, []string{"endpoints"}, leadingPathFields("endpoints.0.host"))
+	require.Equal(t, []string{"endpoints"}, leadingPathFields("{.endpoints[*].host}"))
+}
+
+func TestPathKey(t *testing.T) {
+	require.Equal(t, "image_name", pathKey("image.name"))
+	require.Equal(t, "endpoints_0_host", pathKey("endpoints[0].host"))
+	require.Equal(t, "endpoints_name_primary_host", pathKey(`{.endpoints[?(@.name=="primary")].host}`))
+}
+
+func TestFindPathValues(t *testing.T) {
+	status := map[string]interface{}{
+		"endpoints": []interface{}{
+			map[string]interface{}{"name": "primary", "host": "db-0", "port": int64(5432)},
+			map[string]interface{}{"name": "replica", "host": "db-1", "port": int64(5432)},
+		},
+		"connection": map[string]interface{}{"host": "db", "port": int64(5432)},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected []interface{}
+	}{
+		{name: "index", path: "endpoints[1].host", expected: []interface{}{"db-1"}},
+		{name: "dotted index", path: "endpoints.0.host", expected: []interface{}{"db-0"}},
+		{name: "filter", path: `endpoints[?(@.name=="replica")].host`, expected: []interface{}{"db-1"}},
+		{name: "wildcard", path: "endpoints[*].host", expected: []interface{}{"db-0", "db-1"}},
+		{name: "missing", path: "endpoints[0].user"},
+		{name: "map", path: "connection", expected: []interface{}{status["connection"]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := findPathValues(status, tt.path)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, values)
+		})
+	}
+
+	t.Run("type mismatch", func(t *testing.T) {
+		_, err := findPathValues(status, "connection[0]")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, InvalidPathErr))
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		_, err := findPathValues(status, "endpoints[?(@.name==")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, InvalidPathErr))
+	})
+}
+
+func TestFlattenValues(t *testing.T) {
+	require.Equal(t, map[string]string{"host": "db"}, flattenValues("host", []interface{}{"db"}))
+	require.Equal(t, map[string]string{"host": ""}, flattenValues("host", nil))
+	require.Equal(t, map[string]string{"hosts_0": "db-0", "hosts_1": "db-1"},
+		flattenValues("hosts", []interface{}{"db-0", "db-1"}))
+	require.Equal(t, map[string]string{
+		"connection_host":    "db",
+		"connection_port":    "5432",
+		"connection_users_0": "admin",
+	}, flattenValues("connection", []interface{}{map[string]interface{}{
+		"host":  "db",
+		"port":  int64(5432),
+		"users": []interface{}{"admin"},
+	}}))
+}
@@ -1,9 +1,14 @@
 package servicebindingrequest
 
 import (
-	"github.com/redhat-developer/service-binding-operator/pkg/log"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
 )
 
 var (
@@ -12,26 +17,73 @@ var (
 
 // SBRRequestMapper is the handler.Mapper interface implementation. It should influence the
 // enqueue process considering the resources informed.
-type SBRRequestMapper struct{}
+type SBRRequestMapper struct {
+	gvk          schema.GroupVersionKind // kind of the resources expressing binding intent
+	boundObjects *boundObjectIndex
+}
 
 // Map execute the mapping of a resource with the requests it would produce. Here we inspect the
-// given object trying to identify if this object is part of a SBR, or a actual SBR resource.
+// given object trying to identify if this object is a actual SBR resource, or if SBRs depend on it,
+// in which case all of those are reconciled.
 func (m *SBRRequestMapper) Map(obj handler.MapObject) []reconcile.Request {
+	gvk := obj.Object.GetObjectKind().GroupVersionKind()
 	log := mapperLog.WithValues(
+		"Object.GVK", gvk,
 		"Object.Namespace", obj.Meta.GetNamespace(),
 		"Object.Name", obj.Meta.GetName(),
 	)
 	toReconcile := []reconcile.Request{}
 
-	sbrNamespacedName, err := GetSBRNamespacedNameFromObject(obj.Object)
-	if err != nil {
-		log.Error(err, "on inspecting object for annotations for SBR object")
-		return toReconcile
+	if gvk == m.gvk {
+		log.Debug("Object is a SBR, mapping it to itself")
+		namespacedName := types.NamespacedName{Namespace: obj.Meta.GetNamespace(), Name: obj.Meta.GetName()}
+		return append(toReconcile, reconcile.Request{NamespacedName: namespacedName})
 	}
-	if IsNamespacedNameEmpty(sbrNamespacedName) {
-		log.Debug("not able to extract SBR namespaced-name")
+
+	if m.boundObjects == nil {
 		return toReconcile
 	}
+	ref := objectReferenceFor(gvk, obj.Meta)
+	if gvk.GroupKind() == bindableServiceGVK.GroupKind() {
+		// BindableServices are depended on by the kind they describe
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj.Object)
+		if err != nil {
+			log.Error(err, "on reading BindableService")
+			return toReconcile
+		}
+		group, _, _ := unstructured.NestedString(u, "spec", "group")
+		kind, _, _ := unstructured.NestedString(u, "spec", "kind")
+		ref = bindableServiceReferenceFor(schema.GroupKind{Group: group, Kind: kind})
+	}
+	for _, namespacedName := range m.boundObjects.lookup(ref) {
+		log.Debug("SBR depends on object", "SBR.NamespacedName", namespacedName)
+		toReconcile = append(toReconcile, reconcile.Request{NamespacedName: namespacedName})
+	}
+	if len(toReconcile) == 0 {
+		log.Debug("no SBR depends on object")
+	}
+	return toReconcile
+}
 
-	return append(toReconcile, reconcile.Request{NamespacedName: sbrNamespacedName})
+// ApplicationToSBRMapper maps application objects to the SBRs selecting them, or having them
+// recorded as bound, using the application index.
+type ApplicationToSBRMapper struct {
+	applications *applicationIndex
+}
+
+// Map looks up the SBRs interested in the given application object.
+func (m *ApplicationToSBRMapper) Map(obj handler.MapObject) []reconcile.Request {
+	gvk := obj.Object.GetObjectKind().GroupVersionKind()
+	log := mapperLog.WithValues(
+		"Object.GVK", gvk,
+		"Object.Namespace", obj.Meta.GetNamespace(),
+		"Object.Name", obj.Meta.GetName(),
+	)
+
+	toReconcile := []reconcile.Request{}
+	for _, namespacedName := range m.applications.lookup(gvk, obj.Meta) {
+		log.Debug("Application is selected by SBR", "SBR.NamespacedName", namespacedName)
+		toReconcile = append(toReconcile, reconcile.Request{NamespacedName: namespacedName})
+	}
+	return toReconcile
 }
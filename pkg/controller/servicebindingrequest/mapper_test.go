@@ -11,37 +11,91 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
 )
 
 func TestSBRRequestMapperMap(t *testing.T) {
-	mapper := &SBRRequestMapper{}
+	boundObjects := newBoundObjectIndex()
+	mapper := &SBRRequestMapper{
+		gvk:          v1alpha1.SchemeGroupVersion.WithKind(ServiceBindingRequestKind),
+		boundObjects: boundObjects,
+	}
 
 	u := &unstructured.Unstructured{}
 	u.SetNamespace("mapper-unit")
 	u.SetName("mapper-unit")
 	u.SetGroupVersionKind(schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"})
 
-	// not containing annotations, should return empty
+	// not indexed, should return empty
 	mapObj := handler.MapObject{Meta: u, Object: u.DeepCopyObject()}
 	mappedRequests := mapper.Map(mapObj)
 	require.Equal(t, 0, len(mappedRequests))
 
 	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "name"}}
+	otherRequest := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "other"}}
 
-	// with annotations in place it should return the actual values
+	// legacy annotations are not taken into account anymore
 	u.SetAnnotations(map[string]string{sbrNamespaceAnnotation: "ns", sbrNameAnnotation: "name"})
 	mapObj = handler.MapObject{Meta: u, Object: u.DeepCopyObject()}
 	mappedRequests = mapper.Map(mapObj)
-	require.Equal(t, 1, len(mappedRequests))
-	require.Equal(t, request, mappedRequests[0])
+	require.Equal(t, 0, len(mappedRequests))
+
+	// once indexed, it should return all SBRs depending on the object
+	ref := objectReferenceFor(u.GroupVersionKind(), u)
+	boundObjects.set(request.NamespacedName, map[objectReference][]string{ref: {}})
+	boundObjects.set(otherRequest.NamespacedName, map[objectReference][]string{ref: {}})
+	mappedRequests = mapper.Map(mapObj)
+	require.Equal(t, []reconcile.Request{request, otherRequest}, mappedRequests)
 
 	// it should also understand a actual SBR as well, so return not empty
 	sbr := &unstructured.Unstructured{}
 	sbr.SetGroupVersionKind(v1alpha1.SchemeGroupVersion.WithKind(ServiceBindingRequestKind))
 	sbr.SetNamespace("ns")
 	sbr.SetName("name")
-	mapObj = handler.MapObject{Meta: u, Object: sbr.DeepCopyObject()}
+	mapObj = handler.MapObject{Meta: sbr, Object: sbr.DeepCopyObject()}
 	mappedRequests = mapper.Map(mapObj)
 	require.Equal(t, 1, len(mappedRequests))
 	require.Equal(t, request, mappedRequests[0])
+
+	// BindableServices are mapped to the SBRs depending on the kind they describe, whatever their
+	// name is
+	bs := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"group": "postgresql.baiju.dev", "kind": "Database"},
+	}}
+	bs.SetGroupVersionKind(bindableServiceGVK)
+	bs.SetName("any")
+	gk := schema.GroupKind{Group: "postgresql.baiju.dev", Kind: "Database"}
+	boundObjects.set(request.NamespacedName, map[objectReference][]string{bindableServiceReferenceFor(gk): {}})
+	mapObj = handler.MapObject{Meta: bs, Object: bs.DeepCopyObject()}
+	require.Equal(t, []reconcile.Request{request}, mapper.Map(mapObj))
+
+	require.NoError(t, unstructured.SetNestedField(bs.Object, "Cache", "spec", "kind"))
+	mapObj = handler.MapObject{Meta: bs, Object: bs.DeepCopyObject()}
+	require.Empty(t, mapper.Map(mapObj))
+}
+
+func TestApplicationToSBRMapperMap(t *testing.T) {
+	ns := "mapper-unit"
+	matchLabels := map[string]string{"connects-to": "database"}
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+	index := newApplicationIndex()
+	sbr := mocks.ServiceBindingRequestMock(ns, "sbr", nil, "db", "", deploymentsGVR, matchLabels)
+	require.NoError(t, index.set(sbr, deploymentGVK))
+	mapper := &ApplicationToSBRMapper{applications: index}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(deploymentGVK)
+	u.SetNamespace(ns)
+	u.SetName("app")
+
+	// not selected, should return empty
+	mappedRequests := mapper.Map(handler.MapObject{Meta: u, Object: u})
+	require.Equal(t, 0, len(mappedRequests))
+
+	// selected by labels, should return the SBR
+	u.SetLabels(matchLabels)
+	mappedRequests = mapper.Map(handler.MapObject{Meta: u, Object: u})
+	require.Equal(t, 1, len(mappedRequests))
+	require.Equal(t, types.NamespacedName{Namespace: ns, Name: "sbr"}, mappedRequests[0].NamespacedName)
 }
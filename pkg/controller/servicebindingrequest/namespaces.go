@@ -0,0 +1,218 @@
+package servicebindingrequest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+)
+
+const (
+	// allowBindingsFromAnnotation is the namespace annotation listing, separated by commas, the
+	// namespaces of the service binding requests allowed to bind applications in the namespace, or
+	// "*" to allow all of them.
+	allowBindingsFromAnnotation = "service-binding-operator.apps.openshift.io/allow-bindings-from"
+	// allowConsumersFromAnnotation is the namespace annotation listing, separated by commas, the
+	// namespaces of the service binding requests allowed to consume backing services in the
+	// namespace, or "*" to allow all of them.
+	allowConsumersFromAnnotation = "service-binding-operator.apps.openshift.io/allow-consumers-from"
+	// replicaNamespaceLabel is the label of intermediary secret replicas holding the namespace of
+	// the service binding request they were replicated for.
+	replicaNamespaceLabel = "service-binding-operator.apps.openshift.io/binding-namespace"
+	// replicaNameLabel is the label of intermediary secret replicas holding the name of the service
+	// binding request they were replicated for.
+	replicaNameLabel = "service-binding-operator.apps.openshift.io/binding-name"
+)
+
+var (
+	// ApplicationNamespaceNotAllowedErr is returned when the application namespace doesn't allow
+	// bindings from the service binding request namespace.
+	ApplicationNamespaceNotAllowedErr = errors.New("application namespace doesn't allow bindings")
+	// BackingServiceNamespaceNotAllowedErr is returned when the backing service namespace doesn't
+	// allow being consumed from the service binding request namespace.
+	BackingServiceNamespaceNotAllowedErr = errors.New("backing service namespace doesn't allow consumers")
+	// SecretReplicaConflictErr is returned when a secret not replicated for the service binding
+	// request exists in an application namespace under the intermediary secret name.
+	SecretReplicaConflictErr = errors.New("secret replica conflicts with an existing secret")
+)
+
+// namespacesGVR is the resource of namespaces.
+var namespacesGVR = corev1.SchemeGroupVersion.WithResource("namespaces")
+
+// namespaceAllows returns whether the informed namespace lists the informed namespace in the
+// informed annotation, or allows all namespaces with "*". A namespace always allows itself.
+func namespaceAllows(namespace *unstructured.Unstructured, annotation string, from string) bool {
+	if namespace.GetName() == from {
+		return true
+	}
+	value, ok := namespace.GetAnnotations()[annotation]
+	if !ok {
+		return false
+	}
+	for _, allowed := range strings.Split(value, ",") {
+		allowed = strings.TrimSpace(allowed)
+		if allowed == "*" || allowed == from {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceAllowsFrom reads the informed namespace, and returns whether it lists the informed
+// namespace in the informed annotation. A namespace always allows itself, without being read.
+func namespaceAllowsFrom(client dynamic.Interface, name string, annotation string, from string) (bool, error) {
+	if name == from {
+		return true, nil
+	}
+	ns, err := client.Resource(namespacesGVR).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return namespaceAllows(ns, annotation, from), nil
+}
+
+// checkConsumerNamespace returns an error when the informed backing service namespace doesn't allow
+// being consumed from the informed consumer namespace, so resources of a tenant aren't read on
+// behalf of another one with the operator privileges.
+func checkConsumerNamespace(client dynamic.Interface, ns string, consumer string) error {
+	allowed, err := namespaceAllowsFrom(client, ns, allowConsumersFromAnnotation, consumer)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("%w: namespace '%s' doesn't allow consumers from '%s'",
+			BackingServiceNamespaceNotAllowedErr, ns, consumer)
+	}
+	return nil
+}
+
+// applicationNamespaces returns the namespaces applications are searched in, the namespace
+// informed in the application selector, or the ones selected by its namespace selector, the SBR
+// namespace by default. Selected namespaces not allowing bindings from the SBR namespace are
+// skipped, while an informed namespace not allowing them is an error.
+func (b *Binder) applicationNamespaces(selector v1alpha1.ApplicationSelector) ([]string, error) {
+	from := b.sbr.GetNamespace()
+
+	if selector.NamespaceSelector != nil {
+		nsSelector, err := metav1.LabelSelectorAsSelector(selector.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", InvalidApplicationLabelSelectorErr, err)
+		}
+		nsList, err := b.dynClient.Resource(namespacesGVR).List(metav1.ListOptions{
+			LabelSelector: nsSelector.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		namespaces := []string{}
+		for i := range nsList.Items {
+			ns := &nsList.Items[i]
+			if !namespaceAllows(ns, allowBindingsFromAnnotation, from) {
+				b.logger.Debug("Namespace doesn't allow bindings, skipping it", "Namespace", ns.GetName())
+				continue
+			}
+			namespaces = append(namespaces, ns.GetName())
+		}
+		return namespaces, nil
+	}
+
+	if selector.Namespace == "" {
+		return []string{from}, nil
+	}
+	allowed, err := namespaceAllowsFrom(b.dynClient, selector.Namespace, allowBindingsFromAnnotation, from)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: namespace '%s' doesn't allow bindings from '%s'",
+			ApplicationNamespaceNotAllowedErr, selector.Namespace, from)
+	}
+	return []string{selector.Namespace}, nil
+}
+
+// replicateSecret copies the intermediary secret into the informed namespace, so it can be
+// referred to by applications bound in other namespaces than the SBR's. Replicas are labeled after
+// the SBR they were replicated for.
+func (b *Binder) replicateSecret(ns string) error {
+	secrets := b.dynClient.Resource(corev1.SchemeGroupVersion.WithResource(SecretResource))
+	secret, err := secrets.Namespace(b.sbr.GetNamespace()).Get(b.sbr.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	replica := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	replica.SetAPIVersion(secret.GetAPIVersion())
+	replica.SetKind(secret.GetKind())
+	replica.SetNamespace(ns)
+	replica.SetName(secret.GetName())
+	replica.SetLabels(map[string]string{
+		replicaNamespaceLabel: b.sbr.GetNamespace(),
+		replicaNameLabel:      b.sbr.GetName(),
+	})
+	for _, field := range []string{"type", "data"} {
+		if value, found := secret.Object[field]; found {
+			replica.Object[field] = value
+		}
+	}
+
+	// secrets of the same name not replicated for the SBR belong to someone else, and are left alone
+	existing, err := secrets.Namespace(ns).Get(replica.GetName(), metav1.GetOptions{})
+	if k8serror.IsNotFound(err) {
+		b.logger.Debug("Replicating intermediary secret...", "Namespace", ns)
+		_, err = secrets.Namespace(ns).Create(replica, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if !b.isSecretReplica(existing) {
+		return fmt.Errorf("%w: secret '%s' in namespace '%s' was not replicated for '%s/%s'",
+			SecretReplicaConflictErr, existing.GetName(), ns, b.sbr.GetNamespace(), b.sbr.GetName())
+	}
+	b.logger.Debug("Updating intermediary secret replica...", "Namespace", ns)
+	replica.SetResourceVersion(existing.GetResourceVersion())
+	_, err = secrets.Namespace(ns).Update(replica, metav1.UpdateOptions{})
+	return err
+}
+
+// isSecretReplica evaluates whether the informed secret is labeled as replicated for the SBR.
+func (b *Binder) isSecretReplica(secret *unstructured.Unstructured) bool {
+	labels := secret.GetLabels()
+	return labels[replicaNamespaceLabel] == b.sbr.GetNamespace() && labels[replicaNameLabel] == b.sbr.GetName()
+}
+
+// removeSecretReplicas deletes the intermediary secret replicas in the namespaces of the
+// applications recorded as bound in the SBR status, except the ones in the informed namespaces.
+func (b *Binder) removeSecretReplicas(keep map[string]bool) error {
+	secrets := b.dynClient.Resource(corev1.SchemeGroupVersion.WithResource(SecretResource))
+	for _, app := range b.sbr.Status.ApplicationObjects {
+		ns := app.Namespace
+		if ns == "" || ns == b.sbr.GetNamespace() || keep[ns] {
+			continue
+		}
+		existing, err := secrets.Namespace(ns).Get(b.sbr.GetName(), metav1.GetOptions{})
+		if k8serror.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !b.isSecretReplica(existing) {
+			b.logger.Debug("Skipping secret not replicated for the SBR", "Namespace", ns)
+			continue
+		}
+		b.logger.Debug("Deleting intermediary secret replica...", "Namespace", ns)
+		err = secrets.Namespace(ns).Delete(b.sbr.GetName(), &metav1.DeleteOptions{})
+		if err != nil && !k8serror.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package servicebindingrequest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/converter"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestNamespaceAllows(t *testing.T) {
+	namespace := func(name, allowed string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetName(name)
+		if allowed != "" {
+			u.SetAnnotations(map[string]string{allowBindingsFromAnnotation: allowed})
+		}
+		return u
+	}
+
+	tests := []struct {
+		name      string
+		namespace *unstructured.Unstructured
+		want      bool
+	}{
+		{name: "same namespace", namespace: namespace("shared", ""), want: true},
+		{name: "not annotated", namespace: namespace("tenant", ""), want: false},
+		{name: "listed", namespace: namespace("tenant", "other, shared"), want: true},
+		{name: "not listed", namespace: namespace("tenant", "other"), want: false},
+		{name: "all namespaces", namespace: namespace("tenant", "*"), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, namespaceAllows(tt.namespace, allowBindingsFromAnnotation, "shared"))
+		})
+	}
+}
+
+func TestBinderApplicationNamespaces(t *testing.T) {
+	ns := "shared"
+	tenantLabels := map[string]string{"tenant": "true"}
+
+	f := mocks.NewFake(t, ns)
+	f.AddMockedUnstructuredNamespace("tenant-a", tenantLabels, map[string]string{allowBindingsFromAnnotation: ns})
+	f.AddMockedUnstructuredNamespace("tenant-b", tenantLabels, map[string]string{allowBindingsFromAnnotation: "other"})
+	f.AddMockedUnstructuredNamespace("tenant-c", nil, map[string]string{allowBindingsFromAnnotation: "*"})
+	sbr := mocks.ServiceBindingRequestMock(ns, "sbr", nil, "ref", "app", deploymentsGVR, nil)
+
+	// namespaces returns the application namespaces of the SBR, modified by the informed function
+	namespaces := func(modify func(selector *v1alpha1.ApplicationSelector)) ([]string, error) {
+		s := sbr.DeepCopy()
+		modify(&s.Spec.ApplicationSelector)
+		binder := NewBinder(context.TODO(), f.FakeClient(), f.FakeDynClient(), f.FakeRESTMapper(), s, []VolumeKey{})
+		return binder.applicationNamespaces(s.Spec.ApplicationSelector)
+	}
+
+	t.Run("default", func(t *testing.T) {
+		got, err := namespaces(func(*v1alpha1.ApplicationSelector) {})
+		require.NoError(t, err)
+		require.Equal(t, []string{ns}, got)
+	})
+
+	t.Run("allowed namespace", func(t *testing.T) {
+		got, err := namespaces(func(s *v1alpha1.ApplicationSelector) { s.Namespace = "tenant-c" })
+		require.NoError(t, err)
+		require.Equal(t, []string{"tenant-c"}, got)
+	})
+
+	t.Run("not allowed namespace", func(t *testing.T) {
+		_, err := namespaces(func(s *v1alpha1.ApplicationSelector) { s.Namespace = "tenant-b" })
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ApplicationNamespaceNotAllowedErr))
+	})
+
+	t.Run("missing namespace", func(t *testing.T) {
+		_, err := namespaces(func(s *v1alpha1.ApplicationSelector) { s.Namespace = "missing" })
+		require.Error(t, err)
+		require.True(t, k8serror.IsNotFound(err))
+	})
+
+	t.Run("namespace selector", func(t *testing.T) {
+		got, err := namespaces(func(s *v1alpha1.ApplicationSelector) {
+			s.NamespaceSelector = &metav1.LabelSelector{MatchLabels: tenantLabels}
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"tenant-a"}, got)
+	})
+}
+
+func TestBinderBindOtherNamespace(t *testing.T) {
+	ns := "shared"
+	appNs := "tenant"
+	name := "service-binding-request"
+	secretGVR := corev1.SchemeGroupVersion.WithResource(SecretResource)
+
+	f := mocks.NewFake(t, ns)
+	f.AddMockedUnstructuredNamespace(appNs, nil, map[string]string{allowBindingsFromAnnotation: ns})
+	f.AddMockedUnstructuredNamespace("private", nil, nil)
+	secret, err := converter.ToUnstructured(mocks.SecretMock(ns, name))
+	require.NoError(t, err)
+	f.AddMockResource(secret)
+	app, err := mocks.UnstructuredDeploymentMock(appNs, "app", nil)
+	require.NoError(t, err)
+	f.AddMockResource(app)
+	sbr := mocks.ServiceBindingRequestMock(ns, name, nil, "ref", "app", deploymentsGVR, nil)
+	sbr.Spec.ApplicationSelector.Namespace = appNs
+
+	fakeClient := f.FakeClient()
+	fakeDynClient := f.FakeDynClient()
+	binder := NewBinder(context.TODO(), fakeClient, fakeDynClient, f.FakeRESTMapper(), sbr, []VolumeKey{})
+
+	// bind binds the application, recording it as bound the same way the service binder does
+	bind := func(t *testing.T) []*unstructured.Unstructured {
+		updatedObjects, err := binder.Bind()
+		require.NoError(t, err)
+		sbr.Status.ApplicationObjects = nil
+		for _, obj := range updatedObjects {
+			sbr.Status.ApplicationObjects = append(sbr.Status.ApplicationObjects, v1alpha1.BoundApplication{
+				GroupVersionKind:     metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+				LocalObjectReference: corev1.LocalObjectReference{Name: obj.GetName()},
+				Namespace:            obj.GetNamespace(),
+			})
+		}
+		return updatedObjects
+	}
+
+	// isBound returns whether the application is bound
+	isBound := func(t *testing.T) bool {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: appNs, Name: "app"}, u))
+		_, found := u.GetAnnotations()[injectionsAnnotation]
+		return found
+	}
+
+	// replicaExists returns whether the intermediary secret replica exists in the application namespace
+	replicaExists := func(t *testing.T) bool {
+		_, err := fakeDynClient.Resource(secretGVR).Namespace(appNs).Get(name, metav1.GetOptions{})
+		if k8serror.IsNotFound(err) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+
+	t.Run("bind", func(t *testing.T) {
+		updatedObjects := bind(t)
+		require.Len(t, updatedObjects, 1)
+		require.Equal(t, appNs, updatedObjects[0].GetNamespace())
+		require.True(t, isBound(t))
+
+		replica, err := fakeDynClient.Resource(secretGVR).Namespace(appNs).Get(name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{replicaNamespaceLabel: ns, replicaNameLabel: name}, replica.GetLabels())
+		require.Equal(t, secret.Object["data"], replica.Object["data"])
+	})
+
+	t.Run("unbind", func(t *testing.T) {
+		require.NoError(t, binder.Unbind())
+		require.False(t, isBound(t))
+		require.False(t, replicaExists(t))
+	})
+
+	t.Run("namespace not allowed", func(t *testing.T) {
+		bind(t)
+		require.True(t, replicaExists(t))
+
+		sbr.Spec.ApplicationSelector.Namespace = "private"
+		updatedObjects, err := binder.Bind()
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ApplicationNamespaceNotAllowedErr))
+		require.Empty(t, updatedObjects)
+		require.False(t, isBound(t))
+		require.False(t, replicaExists(t))
+	})
+}
+
+// TestBinderSecretReplicaConflict checks a secret not replicated for the SBR, named after the
+// intermediary secret, is neither updated when binding nor deleted when unbinding.
+func TestBinderSecretReplicaConflict(t *testing.T) {
+	ns := "shared"
+	appNs := "tenant"
+	name := "service-binding-request"
+	secretGVR := corev1.SchemeGroupVersion.WithResource(SecretResource)
+
+	f := mocks.NewFake(t, ns)
+	f.AddMockedUnstructuredNamespace(appNs, nil, map[string]string{allowBindingsFromAnnotation: ns})
+	secret, err := converter.ToUnstructured(mocks.SecretMock(ns, name))
+	require.NoError(t, err)
+	f.AddMockResource(secret)
+	tenantSecret := mocks.SecretMock(appNs, name)
+	tenantSecret.Data = map[string][]byte{"token": []byte("tenant")}
+	f.AddMockResource(tenantSecret)
+	app, err := mocks.UnstructuredDeploymentMock(appNs, "app", nil)
+	require.NoError(t, err)
+	f.AddMockResource(app)
+	sbr := mocks.ServiceBindingRequestMock(ns, name, nil, "ref", "app", deploymentsGVR, nil)
+	sbr.Spec.ApplicationSelector.Namespace = appNs
+
+	fakeDynClient := f.FakeDynClient()
+	binder := NewBinder(context.TODO(), f.FakeClient(), fakeDynClient, f.FakeRESTMapper(), sbr, []VolumeKey{})
+
+	// requireTenantSecret requires the tenant secret to exist untouched
+	requireTenantSecret := func(t *testing.T) {
+		u, err := fakeDynClient.Resource(secretGVR).Namespace(appNs).Get(name, metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Empty(t, u.GetLabels())
+		require.Equal(t, map[string]interface{}{"token": "dGVuYW50"}, u.Object["data"])
+	}
+
+	t.Run("bind", func(t *testing.T) {
+		_, err := binder.Bind()
+		require.Error(t, err)
+		require.True(t, errors.Is(err, SecretReplicaConflictErr))
+		requireTenantSecret(t)
+	})
+
+	t.Run("unbind", func(t *testing.T) {
+		sbr.Status.ApplicationObjects = []v1alpha1.BoundApplication{{
+			GroupVersionKind:     metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			LocalObjectReference: corev1.LocalObjectReference{Name: "app"},
+			Namespace:            appNs,
+		}}
+		require.NoError(t, binder.Unbind())
+		requireTenantSecret(t)
+	})
+}
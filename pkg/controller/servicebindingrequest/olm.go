@@ -115,7 +115,8 @@ func (o *OLM) loopCRDDescriptions(
 	return nil
 }
 
-// SelectCRDByGVK return a single CRD based on a given GVK.
+// SelectCRDByGVK returns a single CRDDescription based on a given GVK, merging the descriptors
+// declared by BindableServices, CSVs and CRD annotations, in that order of precedence.
 func (o *OLM) SelectCRDByGVK(gvk schema.GroupVersionKind, crd *unstructured.Unstructured) (*olmv1alpha1.CRDDescription, error) {
 	log := o.logger.WithValues("Selector.GVK", gvk)
 	ownedCRDs, err := o.ListCSVOwnedCRDs()
@@ -129,7 +130,7 @@ func (o *OLM) SelectCRDByGVK(gvk schema.GroupVersionKind, crd *unstructured.Unst
 	// CRDDescription is both used by OLM to configure OLM descriptors in manifests existing in the
 	// cluster but is also built from annotations present in the CRD
 	if crd != nil {
-		crdDescription, err = buildCRDDescriptionFromCRD(crd)
+		crdDescription, err = buildCRDDescriptionFromCRD(crd, gvk)
 		if err != nil {
 			return nil, err
 		}
@@ -160,19 +161,76 @@ func (o *OLM) SelectCRDByGVK(gvk schema.GroupVersionKind, crd *unstructured.Unst
 		return nil, err
 	}
 
-	if len(crdDescriptions) == 0 && crdDescription == nil {
+	bindableService, err := selectBindableService(o.client, gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	var csvDescription *olmv1alpha1.CRDDescription
+	if len(crdDescriptions) > 0 {
+		csvDescription = crdDescriptions[0]
+	}
+
+	// descriptors are merged in order of precedence: BindableServices, CSV descriptors and CRD
+	// annotations
+	merged := mergeCRDDescriptions(bindableService, csvDescription, crdDescription)
+	if merged == nil {
 		log.Debug("No CRD could be found for GVK.")
 		return nil, fmt.Errorf("no crd could be found for gvk")
-	} else if len(crdDescriptions) == 0 {
-		// use the crdDescription built from CRD annotations as fallback
-		return crdDescription, nil
 	}
+	return merged, nil
+}
 
-	return crdDescriptions[0], nil
+// crdVersions returns the versions served by the informed CRD and its storage version, either from
+// "spec.versions", required in apiextensions.k8s.io/v1, or from the deprecated "spec.version".
+func crdVersions(crd *unstructured.Unstructured) ([]string, string, error) {
+	versions, found, err := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	if err != nil {
+		return nil, "", err
+	}
+	if !found || len(versions) == 0 {
+		version, _, err := unstructured.NestedString(crd.Object, "spec", "version")
+		if err != nil || version == "" {
+			return nil, "", err
+		}
+		return []string{version}, version, nil
+	}
+
+	served := []string{}
+	storage := ""
+	for _, v := range versions {
+		data, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, err := unstructured.NestedString(data, "name")
+		if err != nil {
+			return nil, "", err
+		}
+		isServed, _, err := unstructured.NestedBool(data, "served")
+		if err != nil {
+			return nil, "", err
+		}
+		isStorage, _, err := unstructured.NestedBool(data, "storage")
+		if err != nil {
+			return nil, "", err
+		}
+		if isServed {
+			served = append(served, name)
+		}
+		if isStorage {
+			storage = name
+		}
+	}
+	return served, storage, nil
 }
 
-// buildCRDDescriptionFromCRD builds a CRDDescription from annotations present in the CRD.
-func buildCRDDescriptionFromCRD(crd *unstructured.Unstructured) (*olmv1alpha1.CRDDescription, error) {
+// buildCRDDescriptionFromCRD builds a CRDDescription from annotations present in the CRD, for the
+// version informed in gvk, or the CRD storage version when not informed.
+func buildCRDDescriptionFromCRD(
+	crd *unstructured.Unstructured,
+	gvk schema.GroupVersionKind,
+) (*olmv1alpha1.CRDDescription, error) {
 	var (
 		ok  bool
 		err error
@@ -187,10 +245,24 @@ func buildCRDDescriptionFromCRD(crd *unstructured.Unstructured) (*olmv1alpha1.CR
 		return nil, err
 	}
 
-	crdDescription.Version, ok, err = unstructured.NestedString(crd.Object, "spec", "version")
-	if err != nil || !ok {
+	served, storage, err := crdVersions(crd)
+	if err != nil || len(served) == 0 {
 		return nil, err
 	}
+	crdDescription.Version = storage
+	if gvk.Version != "" {
+		crdDescription.Version = ""
+		for _, v := range served {
+			if strings.EqualFold(v, gvk.Version) {
+				crdDescription.Version = v
+			}
+		}
+		if crdDescription.Version == "" {
+			return nil, fmt.Errorf(
+				"%w: version '%s' of CRD '%s', served versions are %v",
+				CRDVersionNotServedErr, gvk.Version, crd.GetName(), served)
+		}
+	}
 
 	specDescriptors, statusDescriptors, err := buildDescriptorsFromAnnotations(crd.GetAnnotations())
 	if err != nil {
@@ -242,7 +314,10 @@ func buildDescriptorsFromAnnotations(annotations map[string]string) (
 	// create the status and/or spec descriptors based on the
 	for fieldPath, descriptors := range acc {
 		sort.Strings(descriptors)
-		path := strings.Split(fieldPath, ".")
+		path := strings.SplitN(fieldPath, ".", 2)
+		if len(path) < 2 {
+			continue
+		}
 		if path[0] == "status" {
 			statusDescriptors = append(statusDescriptors, olmv1alpha1.StatusDescriptor{
 				Path:         path[1],
@@ -1,6 +1,7 @@
 package servicebindingrequest
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -99,8 +100,10 @@ func TestAnnotationParsing(t *testing.T) {
 			"status": map[string]interface{}{},
 		},
 	}
+	gvk := schema.GroupVersionKind{Group: "app.dev", Version: "v1", Kind: "Carp"}
+
 	t.Run("Build CSV from CRD - no annotations", func(t *testing.T) {
-		crdDescription, err := buildCRDDescriptionFromCRD(crd)
+		crdDescription, err := buildCRDDescriptionFromCRD(crd, gvk)
 		require.NoError(t, err)
 		require.Len(t, crdDescription.SpecDescriptors, 0)
 		require.Len(t, crdDescription.StatusDescriptors, 0)
@@ -110,7 +113,7 @@ func TestAnnotationParsing(t *testing.T) {
 		crd.Object["metadata"] = map[string]interface{}{
 			"annotations": annotations,
 		}
-		crdDescription, err := buildCRDDescriptionFromCRD(crd)
+		crdDescription, err := buildCRDDescriptionFromCRD(crd, gvk)
 		require.NoError(t, err)
 
 		require.Len(t, crdDescription.StatusDescriptors, 2)
@@ -131,3 +134,70 @@ func TestAnnotationParsing(t *testing.T) {
 		}
 	})
 }
+
+func TestBuildCRDDescriptionFromCRDVersions(t *testing.T) {
+	annotations := map[string]interface{}{
+		"servicebindingoperator.redhat.io/status.dbCredentials-db.password": "binding:env:object:secret",
+	}
+	crd := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind":       "CustomResourceDefinition",
+			"metadata": map[string]interface{}{
+				"name":        "carps.app.dev",
+				"annotations": annotations,
+			},
+			"spec": map[string]interface{}{
+				"names": map[string]interface{}{
+					"kind": "Carp",
+				},
+				"group": "app.dev",
+				"versions": []interface{}{
+					map[string]interface{}{"name": "v1alpha1", "served": false, "storage": false},
+					map[string]interface{}{"name": "v1beta1", "served": true, "storage": false},
+					map[string]interface{}{"name": "v1", "served": true, "storage": true},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		version     string
+		wantVersion string
+		wantErr     error
+	}{
+		{name: "served version", version: "v1beta1", wantVersion: "v1beta1"},
+		{name: "storage version when not informed", version: "", wantVersion: "v1"},
+		{name: "version not served", version: "v1alpha1", wantErr: CRDVersionNotServedErr},
+		{name: "unknown version", version: "v2", wantErr: CRDVersionNotServedErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gvk := schema.GroupVersionKind{Group: "app.dev", Version: tt.version, Kind: "Carp"}
+			crdDescription, err := buildCRDDescriptionFromCRD(crd, gvk)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantVersion, crdDescription.Version)
+			require.Equal(t, "Carp", crdDescription.Kind)
+			require.Len(t, crdDescription.StatusDescriptors, 1)
+		})
+	}
+}
+
+func TestBuildDescriptorsFromAnnotationsNestedPaths(t *testing.T) {
+	specDescriptors, statusDescriptors, err := buildDescriptorsFromAnnotations(map[string]string{
+		"servicebindingoperator.redhat.io/status.endpoints.0.host": "binding:env:attribute",
+		"servicebindingoperator.redhat.io/spec.image.name":         "binding:env:attribute",
+	})
+	require.NoError(t, err)
+	require.Len(t, specDescriptors, 1)
+	require.Equal(t, "image.name", specDescriptors[0].Path)
+	require.Len(t, statusDescriptors, 1)
+	require.Equal(t, "endpoints.0.host", statusDescriptors[0].Path)
+}
@@ -3,6 +3,7 @@ package servicebindingrequest
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,15 +18,20 @@ import (
 var (
 	plannerLog                 = log.NewLog("planner")
 	errBackingServiceNamespace = errors.New("backing Service Namespace is unspecified")
+
+	// CRDVersionNotServedErr is returned when the backing service version is not served by its CRD.
+	CRDVersionNotServedErr = errors.New("backing service version is not served")
 )
 
 // Planner plans resources needed to bind a given backend service, using OperatorLifecycleManager
 // standards and CustomResourceDefinitionDescription data to understand which attributes are needed.
 type Planner struct {
-	ctx    context.Context                 // request context
-	client dynamic.Interface               // kubernetes dynamic api client
-	sbr    *v1alpha1.ServiceBindingRequest // instantiated service binding request
-	logger *log.Log                        // logger instance
+	ctx        context.Context                 // request context
+	client     dynamic.Interface               // kubernetes dynamic api client
+	restMapper meta.RESTMapper                 // maps kinds to resources
+	sbr        *v1alpha1.ServiceBindingRequest // instantiated service binding request
+	access     *accessChecker                  // checks the requester can read backing services
+	logger     *log.Log                        // logger instance
 }
 
 // Plan outcome, after executing planner.
@@ -38,13 +44,16 @@ type Plan struct {
 
 // searchCR based on a CustomResourceDefinitionDescription and name, search for the object.
 func (p *Planner) searchCR(selector v1alpha1.BackingServiceSelector) (*unstructured.Unstructured, error) {
-	// gvr is the plural guessed resource for the given selector
 	gvk := schema.GroupVersionKind{
 		Group:   selector.Group,
 		Version: selector.Version,
 		Kind:    selector.Kind,
 	}
-	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	// gvr is the resource serving the given selector
+	gvr, err := resourceForKind(p.restMapper, gvk)
+	if err != nil {
+		return nil, err
+	}
 
 	if selector.Namespace == nil {
 		return nil, errBackingServiceNamespace
@@ -54,21 +63,56 @@ func (p *Planner) searchCR(selector v1alpha1.BackingServiceSelector) (*unstructu
 	return p.client.Resource(gvr).Namespace(*selector.Namespace).Get(selector.ResourceRef, metav1.GetOptions{})
 }
 
+// checkRead checks whether the user requesting the SBR is allowed to read the backing service
+// informed by the selector, recording the read as denied otherwise.
+func (p *Planner) checkRead(selector v1alpha1.BackingServiceSelector) error {
+	gvk := schema.GroupVersionKind{Group: selector.Group, Version: selector.Version, Kind: selector.Kind}
+	gvr, err := resourceForKind(p.restMapper, gvk)
+	if err != nil {
+		return err
+	}
+	_, err = p.access.canRead("get", gvr, *selector.Namespace, selector.ResourceRef)
+	return err
+}
+
+// DeniedReads returns the backing service reads denied to the user requesting the SBR.
+func (p *Planner) DeniedReads() []v1alpha1.DeniedRead {
+	return p.access.denied
+}
+
 // CRDGVR is the plural GVR for Kubernetes CRDs.
 var CRDGVR = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// CRDV1beta1GVR is the plural GVR for Kubernetes CRDs served by clusters not supporting
+// apiextensions.k8s.io/v1 yet.
+var CRDV1beta1GVR = schema.GroupVersionResource{
 	Group:    "apiextensions.k8s.io",
 	Version:  "v1beta1",
 	Resource: "customresourcedefinitions",
 }
 
-// searchCRD returns the CRD related to the gvk.
+// searchCRD returns the CRD related to the gvk, read through the CRD API version the cluster
+// prefers.
 func (p *Planner) searchCRD(gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
-	// gvr is the plural guessed resource for the given GVK
-	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	// gvr is the resource serving the given GVK
+	gvr, err := resourceForKind(p.restMapper, gvk)
+	if err != nil {
+		return nil, err
+	}
+	// crdGVR is the CustomResourceDefinition resource in the version served by the cluster
+	crdGVR, err := resourceForKind(p.restMapper, crdGroupKind.WithVersion(""))
+	if err != nil {
+		return nil, err
+	}
 	// crdName is the string'fied GroupResource, e.g. "deployments.apps"
 	crdName := gvr.GroupResource().String()
+
 	// delegate the search to the CustomResourceDefinition resource client
-	return p.client.Resource(CRDGVR).Get(crdName, metav1.GetOptions{})
+	return p.client.Resource(crdGVR).Get(crdName, metav1.GetOptions{})
 }
 
 var EmptyBackingServiceSelectorsErr = errors.New("backing service selectors are empty")
@@ -88,11 +132,62 @@ func (p *Planner) Plan() (*Plan, error) {
 		return nil, EmptyBackingServiceSelectorsErr
 	}
 
+	// backing services are read with the operator privileges, so those the requester can't read
+	// are all reported before any is read
+	for i := range selectors {
+		if selectors[i].Namespace == nil {
+			selectors[i].Namespace = &ns
+		}
+		if err := checkConsumerNamespace(p.client, *selectors[i].Namespace, ns); err != nil {
+			return nil, err
+		}
+		if err := p.checkRead(selectors[i]); err != nil {
+			return nil, err
+		}
+	}
+	if denied := p.DeniedReads(); len(denied) > 0 {
+		if p.access.requesterUnknown() {
+			return nil, fmt.Errorf("%w: %s, %d backing service(s) outside namespace '%s' can't be read",
+				ReadAccessDeniedErr, unknownRequesterReason, len(denied), ns)
+		}
+		return nil, fmt.Errorf("%w: %d backing service(s) can't be read by the requester",
+			ReadAccessDeniedErr, len(denied))
+	}
+
 	relatedResources := make([]*RelatedResource, 0)
 	for _, s := range selectors {
+		cr, err := p.searchCR(s)
+		if err != nil {
+			return nil, err
+		}
+
+		// Provisioned Services publish their binding secret, so CRD metadata is not needed
+		bindingSecret, found, err := provisionedServiceSecret(cr)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			r := &RelatedResource{
+				CR:            cr,
+				BindingSecret: bindingSecret,
+				ID:            s.ID,
+				EnvVarPrefix:  s.EnvVarPrefix,
+			}
+			relatedResources = append(relatedResources, r)
+			p.logger.Debug("Resolved Provisioned Service", "RelatedResource", r)
+			continue
+		}
 
 		bssGVK := schema.GroupVersionKind{Kind: s.Kind, Version: s.Version, Group: s.Group}
 
+		// core kinds have built-in extraction rules, and no CRD describing them
+		if isCoreBackingService(bssGVK.GroupKind()) {
+			r := &RelatedResource{CR: cr, ID: s.ID, EnvVarPrefix: s.EnvVarPrefix}
+			relatedResources = append(relatedResources, r)
+			p.logger.Debug("Resolved core backing service", "RelatedResource", r)
+			continue
+		}
+
 		// resolve the CRD using the service's GVK
 		crd, err := p.searchCRD(bssGVK)
 		if err != nil {
@@ -108,17 +203,11 @@ func (p *Planner) Plan() (*Plan, error) {
 		}
 		p.logger.Debug("Resolved CRDDescription", "CRDDescription", crdDescription)
 
-		if s.Namespace == nil {
-			s.Namespace = &ns
-		}
-		cr, err := p.searchCR(s)
-		if err != nil {
-			return nil, err
-		}
-
 		r := &RelatedResource{
 			CRDDescription: crdDescription,
 			CR:             cr,
+			ID:             s.ID,
+			EnvVarPrefix:   s.EnvVarPrefix,
 		}
 		relatedResources = append(relatedResources, r)
 		p.logger.Debug("Resolved related resource", "RelatedResource", r)
@@ -136,12 +225,15 @@ func (p *Planner) Plan() (*Plan, error) {
 func NewPlanner(
 	ctx context.Context,
 	client dynamic.Interface,
+	restMapper meta.RESTMapper,
 	sbr *v1alpha1.ServiceBindingRequest,
 ) *Planner {
 	return &Planner{
-		ctx:    ctx,
-		client: client,
-		sbr:    sbr,
-		logger: plannerLog,
+		ctx:        ctx,
+		client:     client,
+		restMapper: restMapper,
+		sbr:        sbr,
+		access:     newAccessChecker(client, sbr),
+		logger:     plannerLog,
 	}
 }
@@ -2,9 +2,11 @@ package servicebindingrequest
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
@@ -34,7 +36,7 @@ func TestPlanner(t *testing.T) {
 	f.AddMockedDatabaseCR(resourceRef, ns)
 	f.AddMockedUnstructuredDatabaseCRD()
 
-	planner = NewPlanner(context.TODO(), f.FakeDynClient(), sbr)
+	planner = NewPlanner(context.TODO(), f.FakeDynClient(), f.FakeRESTMapper(), sbr)
 	require.NotNil(t, planner)
 
 	// Out of the box, our mocks don't set the namespace
@@ -83,8 +85,16 @@ func TestPlannerWithExplicitBackingServiceNamespace(t *testing.T) {
 	f.AddMockedUnstructuredCSV("cluster-service-version")
 	f.AddMockedDatabaseCR(resourceRef, backingServiceNamespace)
 	f.AddMockedUnstructuredDatabaseCRD()
+	f.AddMockedUnstructuredNamespace(backingServiceNamespace, nil, map[string]string{
+		allowConsumersFromAnnotation: "other, " + ns,
+	})
+
+	// the backing service in the other namespace is read on behalf of the requester
+	setRequester(t, sbr, "tenant")
+	fakeDynClient := f.FakeDynClient()
+	allowReads(fakeDynClient, "tenant")
 
-	planner = NewPlanner(context.TODO(), f.FakeDynClient(), sbr)
+	planner = NewPlanner(context.TODO(), fakeDynClient, f.FakeRESTMapper(), sbr)
 	require.NotNil(t, planner)
 
 	t.Run("searchCR", func(t *testing.T) {
@@ -105,6 +115,51 @@ func TestPlannerWithExplicitBackingServiceNamespace(t *testing.T) {
 		require.Equal(t, name, plan.Name)
 
 	})
+
+	t.Run("plan : backing service namespace not allowing consumers", func(t *testing.T) {
+		other := sbr.DeepCopy()
+		other.SetNamespace("other-consumer")
+		plan, err := NewPlanner(context.TODO(), f.FakeDynClient(), f.FakeRESTMapper(), other).Plan()
+		require.Error(t, err)
+		require.True(t, errors.Is(err, BackingServiceNamespaceNotAllowedErr))
+		require.Nil(t, plan)
+	})
+
+	t.Run("plan : backing service read denied to the requester", func(t *testing.T) {
+		denied := sbr.DeepCopy()
+		setRequester(t, denied, "developer")
+		p := NewPlanner(context.TODO(), f.FakeDynClient(), f.FakeRESTMapper(), denied)
+		plan, err := p.Plan()
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ReadAccessDeniedErr))
+		require.Nil(t, plan)
+		require.Equal(t, []v1alpha1.DeniedRead{{
+			Verb:      "get",
+			Group:     "postgresql.baiju.dev",
+			Resource:  "databases",
+			Namespace: backingServiceNamespace,
+			Name:      resourceRef,
+		}}, p.DeniedReads())
+	})
+
+	t.Run("plan : backing service in other namespace with requester unknown", func(t *testing.T) {
+		unknown := sbr.DeepCopy()
+		unknown.SetAnnotations(nil)
+		p := NewPlanner(context.TODO(), fakeDynClient, f.FakeRESTMapper(), unknown)
+		plan, err := p.Plan()
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ReadAccessDeniedErr))
+		require.Contains(t, err.Error(), unknownRequesterReason)
+		require.Nil(t, plan)
+		require.Equal(t, []v1alpha1.DeniedRead{{
+			Verb:      "get",
+			Group:     "postgresql.baiju.dev",
+			Resource:  "databases",
+			Namespace: backingServiceNamespace,
+			Name:      resourceRef,
+			Reason:    unknownRequesterReason,
+		}}, p.DeniedReads())
+	})
 }
 
 func TestPlannerAnnotation(t *testing.T) {
@@ -120,7 +175,7 @@ func TestPlannerAnnotation(t *testing.T) {
 	f.AddMockedUnstructuredDatabaseCRD()
 	cr := f.AddMockedDatabaseCR("database", ns)
 
-	planner = NewPlanner(context.TODO(), f.FakeDynClient(), sbr)
+	planner = NewPlanner(context.TODO(), f.FakeDynClient(), f.FakeRESTMapper(), sbr)
 	require.NotNil(t, planner)
 
 	t.Run("searchCRD", func(t *testing.T) {
@@ -128,5 +183,39 @@ func TestPlannerAnnotation(t *testing.T) {
 
 		require.NoError(t, err)
 		require.NotNil(t, crd)
+		require.Equal(t, CRDV1beta1GVR.GroupVersion().String(), crd.GetAPIVersion())
+	})
+}
+
+func TestPlannerAnnotationCRDV1(t *testing.T) {
+	ns := "planner"
+	name := "service-binding-request"
+	resourceRef := "db-testing"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "planner",
+	}
+	f := mocks.NewFake(t, ns)
+	sbr := f.AddMockedServiceBindingRequest(name, nil, resourceRef, "", deploymentsGVR, matchLabels)
+	f.AddMockedUnstructuredDatabaseCRDV1()
+	f.AddMockedDatabaseCR(resourceRef, ns)
+
+	planner = NewPlanner(context.TODO(), f.FakeDynClient(), f.FakeRESTMapper(), sbr)
+	require.NotNil(t, planner)
+
+	t.Run("searchCRD", func(t *testing.T) {
+		gvk := schema.GroupVersionKind{Group: mocks.CRDName, Version: mocks.CRDVersion, Kind: mocks.CRDKind}
+		crd, err := planner.searchCRD(gvk)
+		require.NoError(t, err)
+		require.Equal(t, CRDGVR.GroupVersion().String(), crd.GetAPIVersion())
+	})
+
+	t.Run("plan", func(t *testing.T) {
+		plan, err := planner.Plan()
+		require.NoError(t, err)
+		require.Len(t, plan.RelatedResources, 1)
+		crdDescription := plan.RelatedResources[0].CRDDescription
+		require.Equal(t, mocks.CRDVersion, crdDescription.Version)
+		require.Len(t, crdDescription.StatusDescriptors, 1)
 	})
 }
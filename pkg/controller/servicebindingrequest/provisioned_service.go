@@ -0,0 +1,66 @@
+package servicebindingrequest
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// bindingSecretStatusPath is the status path Provisioned Services publish their binding secret name
+// on, following the servicebinding.io duck type.
+const bindingSecretStatusPath = "binding.name"
+
+// provisionedServiceSecret returns the name of the binding secret published by the informed backing
+// service CR, and whether the CR is a Provisioned Service at all.
+func provisionedServiceSecret(cr *unstructured.Unstructured) (string, bool, error) {
+	name, found, err := unstructured.NestedString(cr.Object, statusPathFields(bindingSecretStatusPath)...)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to read '%s' in '%s': %s", bindingSecretStatusPath, cr.GetName(), err)
+	}
+	return name, found && name != "", nil
+}
+
+// ReadBindingSecret reads all items of the binding secret published by the informed Provisioned
+// Service CR, storing them under their own keys, unless its selector informs an id or a prefix to
+// namespace them with.
+func (r *Retriever) ReadBindingSecret(cr *unstructured.Unstructured, name string) error {
+	log := r.logger.WithValues("CR.Name", cr.GetName(), "Secret.Name", name)
+	log.Debug("Reading Provisioned Service binding secret...")
+
+	if err := r.checkNamespace(cr.GetNamespace()); err != nil {
+		return err
+	}
+	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}
+	if allowed, err := r.access.canRead("get", gvr, cr.GetNamespace(), name); err != nil || !allowed {
+		log.Debug("Skipping binding secret the requester can't read")
+		return err
+	}
+	secret, err := r.client.Resource(gvr).Namespace(cr.GetNamespace()).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	data, _, err := unstructured.NestedStringMap(secret.Object, "data")
+	if err != nil {
+		return err
+	}
+	s := r.service(cr)
+	namespaced := s != nil && (s.ID != "" || s.EnvVarPrefix != nil)
+	for k, v := range data {
+		value, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return err
+		}
+		if namespaced {
+			r.store(cr, k, value)
+		} else {
+			r.put(cr, k, value)
+		}
+	}
+
+	r.Objects = append(r.Objects, secret)
+	return nil
+}
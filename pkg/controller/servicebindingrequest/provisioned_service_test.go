@@ -0,0 +1,97 @@
+package servicebindingrequest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestProvisionedServiceSecret(t *testing.T) {
+	ns := "provisioned"
+
+	cr, err := mocks.UnstructuredProvisionedServiceMock(ns, "db", "db-binding")
+	require.NoError(t, err)
+	name, found, err := provisionedServiceSecret(cr)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "db-binding", name)
+
+	cr, err = mocks.UnstructuredDatabaseCRMock(ns, "db")
+	require.NoError(t, err)
+	_, found, err = provisionedServiceSecret(cr)
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+// TestPlannerProvisionedService checks Provisioned Services are planned without a CRD or CSV
+// describing them.
+func TestPlannerProvisionedService(t *testing.T) {
+	ns := "provisioned"
+	f := mocks.NewFake(t, ns)
+	sbr := f.AddMockedServiceBindingRequest("sbr", nil, "db", "", deploymentsGVR, nil)
+	sbr.Spec.BackingServiceSelectors = &[]v1alpha1.BackingServiceSelector{*sbr.Spec.BackingServiceSelector}
+	f.AddMockedUnstructuredProvisionedService("db", "db-binding")
+
+	plan, err := NewPlanner(context.TODO(), f.FakeDynClient(), f.FakeRESTMapper(), sbr).Plan()
+	require.NoError(t, err)
+	require.Len(t, plan.RelatedResources, 2)
+	for _, r := range plan.RelatedResources {
+		require.Equal(t, "db-binding", r.BindingSecret)
+		require.Nil(t, r.CRDDescription)
+	}
+}
+
+// TestReconcileProvisionedService checks the keys of the binding secret published by a Provisioned
+// Service are bound as they are.
+func TestReconcileProvisionedService(t *testing.T) {
+	backingServiceResourceRef := "provisioned-db"
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredServiceBindingRequest(reconcilerName, backingServiceResourceRef, reconcilerName, deploymentsGVR, nil)
+	f.AddMockedUnstructuredProvisionedService(backingServiceResourceRef, "db-binding")
+	f.AddMockedUnstructuredDeployment(reconcilerName, nil)
+	f.AddMockedSecret("db-binding")
+
+	fakeDynClient := f.FakeDynClient()
+	boundObjects := newBoundObjectIndex()
+	reconciler := &Reconciler{
+		client:       f.FakeClient(),
+		dynClient:    fakeDynClient,
+		restMapper:   f.FakeRESTMapper(),
+		scheme:       f.S,
+		boundObjects: boundObjects,
+	}
+
+	res, err := reconciler.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	sbr, err := reconciler.getServiceBindingRequest(namespacedName)
+	require.NoError(t, err)
+	require.Equal(t, BindingSuccess, sbr.Status.BindingStatus)
+
+	secret, err := fakeDynClient.Resource(corev1.SchemeGroupVersion.WithResource(SecretResource)).
+		Namespace(reconcilerNs).
+		Get(sbr.Status.Secret, metav1.GetOptions{})
+	require.NoError(t, err)
+	data := secret.Object["data"].(map[string]interface{})
+	require.Contains(t, data, "user")
+	require.Contains(t, data, "password")
+
+	// the Provisioned Service is indexed, so its status changes trigger the reconciliation
+	db := objectReference{
+		Group:     mocks.CRDName,
+		Kind:      mocks.CRDKind,
+		Namespace: reconcilerNs,
+		Name:      backingServiceResourceRef,
+	}
+	_, found := boundObjects.statusPaths(db)
+	require.True(t, found)
+}
@@ -1,13 +1,17 @@
 package servicebindingrequest
 
 import (
+	"errors"
+
 	v1 "github.com/openshift/custom-resource-status/conditions/v1"
 	"github.com/redhat-developer/service-binding-operator/pkg/conditions"
 	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -17,56 +21,80 @@ import (
 
 // Reconciler reconciles a ServiceBindingRequest object
 type Reconciler struct {
-	client    client.Client     // kubernetes api client
-	dynClient dynamic.Interface // kubernetes dynamic api client
-	scheme    *runtime.Scheme   // api scheme
+	client       client.Client        // kubernetes api client
+	dynClient    dynamic.Interface    // kubernetes dynamic api client
+	restMapper   meta.RESTMapper      // maps kinds to resources
+	scheme       *runtime.Scheme      // api scheme
+	recorder     record.EventRecorder // kubernetes event recorder
+	boundObjects *boundObjectIndex    // index of objects bindings depend on, when informed
+	requests     bindingRequests      // kind of resources reconciled, ServiceBindingRequests when nil
 }
 
 // reconcilerLog local logger instance
 var reconcilerLog = log.NewLog("reconciler")
 
-//// validateServiceBindingRequest check for unsupported settings in SBR.
-//func (r *Reconciler) validateServiceBindingRequest(sbr *v1alpha1.ServiceBindingRequest) error {
-//	// check if application ResourceRef and MatchLabels, one of them is required.
-//	if sbr.Spec.ApplicationSelector.ResourceRef == "" &&
-//		sbr.Spec.ApplicationSelector.LabelSelector == nil {
-//		return fmt.Errorf("both ResourceRef and LabelSelector are not set")
-//	}
-//	return nil
-//}
-
-// getServiceBindingRequest retrieve the SBR object based on namespaced-name.
+// bindingRequests returns the client of the kind of resources reconciled.
+func (r *Reconciler) bindingRequests() bindingRequests {
+	return requestsOrDefault(r.requests, r.dynClient)
+}
+
+// getServiceBindingRequest retrieve the SBR object based on namespaced-name, translated from the
+// kind of resources reconciled.
 func (r *Reconciler) getServiceBindingRequest(
 	namespacedName types.NamespacedName,
 ) (*v1alpha1.ServiceBindingRequest, error) {
-	gr := v1alpha1.SchemeGroupVersion.WithResource(ServiceBindingRequestResource)
-	resourceClient := r.dynClient.Resource(gr).Namespace(namespacedName.Namespace)
-	u, err := resourceClient.Get(namespacedName.Name, metav1.GetOptions{})
-	if err != nil {
-		return nil, err
-	}
+	sbr, _, err := r.bindingRequests().Get(namespacedName)
+	return sbr, err
+}
 
-	sbr := &v1alpha1.ServiceBindingRequest{}
-	err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, sbr)
-	if err != nil {
-		return nil, err
+// index records the objects the SBR depends on, so changes on those trigger its reconciliation.
+func (r *Reconciler) index(namespacedName types.NamespacedName, dependencies map[objectReference][]string) {
+	if r.boundObjects != nil {
+		r.boundObjects.set(namespacedName, dependencies)
 	}
+}
 
-	return sbr, nil
+// unindex removes the objects the SBR depends on from the index.
+func (r *Reconciler) unindex(namespacedName types.NamespacedName) {
+	if r.boundObjects != nil {
+		r.boundObjects.delete(namespacedName)
+	}
 }
 
 // unbind removes the relationship between the given sbr and the manifests the operator has
 // previously modified. This process also deletes any manifests created to support the binding
-// functionality, such as ConfigMaps and Secrets.
-func (r *Reconciler) unbind(logger *log.Log, bm *ServiceBinder) (reconcile.Result, error) {
+// functionality, such as ConfigMaps and Secrets. It doesn't depend on backing services, since those
+// may have been removed before the sbr.
+func (r *Reconciler) unbind(
+	logger *log.Log,
+	sbr *v1alpha1.ServiceBindingRequest,
+) (
+	reconcile.Result,
+	error,
+) {
 	logger = logger.WithName("unbind")
 
 	// when finalizer is not found anymore, it can be safely removed
-	if !containsStringSlice(bm.SBR.GetFinalizers(), Finalizer) {
+	if !containsStringSlice(sbr.GetFinalizers(), Finalizer) {
 		logger.Info("Resource can be safely deleted!")
 		return Done()
 	}
 
+	options := &ServiceBinderOptions{
+		Client:     r.client,
+		DynClient:  r.dynClient,
+		RESTMapper: r.restMapper,
+		SBR:        sbr,
+		Logger:     logger,
+		Requests:   r.requests,
+	}
+
+	bm, err := BuildServiceUnbinder(options)
+	if err != nil {
+		logger.Error(err, "Creating unbinding context")
+		return RequeueError(err)
+	}
+
 	logger.Info("Executing unbinding steps...")
 	if res, err := bm.Unbind(); err != nil {
 		logger.Error(err, "On unbinding application.")
@@ -93,6 +121,37 @@ func (r *Reconciler) bind(
 	return bm.Bind()
 }
 
+// buildFailure describes how an error building the service binder is reported in the SBR status.
+type buildFailure struct {
+	err    error  // error the failure is identified by
+	reason string // reason of the BindingReady condition
+	// requeueLater is whether the SBR is inspected again after a while, since what the failure
+	// depends on isn't watched; the error is returned otherwise, being retried with backoff
+	requeueLater bool
+}
+
+// buildFailures are the errors building the service binder reported in the SBR status.
+var buildFailures = []buildFailure{
+	// the kind may be served later on, once its CRD is installed
+	{err: UnknownKindErr, reason: UnknownKind},
+	// namespaces aren't watched, so the backing service namespace is inspected again later on
+	{err: BackingServiceNamespaceNotAllowedErr, reason: BackingServiceNamespaceNotAllowed, requeueLater: true},
+	// permissions aren't watched, so the backing services are inspected again later on
+	{err: ReadAccessDeniedErr, reason: ReadAccessDenied, requeueLater: true},
+	// backing services aren't indexed until bound, so those are inspected again later on
+	{err: KeyCollisionErr, reason: KeyCollision, requeueLater: true},
+}
+
+// buildFailureFor returns the build failure the informed error is identified as, if any.
+func buildFailureFor(err error) *buildFailure {
+	for i := range buildFailures {
+		if errors.Is(err, buildFailures[i].err) {
+			return &buildFailures[i]
+		}
+	}
+	return nil
+}
+
 // Reconcile a ServiceBindingRequest by the following steps:
 // 1. Inspecting SBR in order to identify backend service. The service is composed by a CRD name and
 //    kind, and by inspecting "connects-to" label identify the name of service instance;
@@ -112,33 +171,38 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 	logger.Info("Reconciling ServiceBindingRequest...")
 
 	// fetch and validate namespaced ServiceBindingRequest instance
-	sbr, err := r.getServiceBindingRequest(request.NamespacedName)
+	sbr, secretKeyEnv, err := r.bindingRequests().Get(request.NamespacedName)
 	if err != nil {
 		logger.Error(err, "On retrieving service-binding-request instance.")
+		if k8serrors.IsNotFound(err) {
+			r.unindex(request.NamespacedName)
+		}
 		return DoneOnNotFound(err)
 	}
 
-	// validate namespaced ServiceBindingRequest instance (this check has been disabled until test data has been
-	// adjusted to reflect the validation)
-	//
-	//if err = r.validateServiceBindingRequest(sbr); err != nil {
-	//	logger.Error(err, "On validating service-binding-request instance.")
-	//	return Done()
-	//}
-
 	logger = logger.WithValues("ServiceBindingRequest.Name", sbr.Name)
 	logger.Debug("Found service binding request to inspect")
 
+	if sbr.GetDeletionTimestamp() != nil {
+		logger.Info("Resource is marked for deletion...")
+		r.unindex(request.NamespacedName)
+		return r.unbind(logger, sbr)
+	}
+
 	// splitting instance from it's status
 	sbrStatus := &sbr.Status
 
 	options := &ServiceBinderOptions{
 		Client:                 r.client,
 		DynClient:              r.dynClient,
+		RESTMapper:             r.restMapper,
 		DetectBindingResources: sbr.Spec.DetectBindingResources,
 		EnvVarPrefix:           sbr.Spec.EnvVarPrefix,
 		SBR:                    sbr,
 		Logger:                 logger,
+		Recorder:               r.recorder,
+		Requests:               r.requests,
+		SecretKeyEnv:           secretKeyEnv,
 	}
 
 	bm, err := BuildServiceBinder(options)
@@ -159,18 +223,29 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 				Reason:  reason,
 				Message: err.Error(),
 			})
-			_, updateErr := updateServiceBindingRequestStatus(r.dynClient, sbr)
+			_, updateErr := r.bindingRequests().UpdateStatus(sbr)
 			if updateErr == nil {
 				return Done()
 			}
 		}
+		if failure := buildFailureFor(err); failure != nil {
+			v1.SetStatusCondition(&sbr.Status.Conditions, v1.Condition{
+				Type:    conditions.BindingReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  failure.reason,
+				Message: err.Error(),
+			})
+			if _, updateErr := r.bindingRequests().UpdateStatus(sbr); updateErr != nil {
+				return RequeueError(updateErr)
+			}
+			if failure.requeueLater {
+				return Requeue(nil, requeueAfter)
+			}
+		}
 		return RequeueError(err)
 	}
 
-	if sbr.GetDeletionTimestamp() != nil {
-		logger.Info("Resource is marked for deletion...")
-		return r.unbind(logger, bm)
-	}
+	r.index(request.NamespacedName, bm.Dependencies)
 
 	logger.Info("Starting the bind of application(s) with backing service...")
 	return r.bind(logger, bm, sbrStatus)
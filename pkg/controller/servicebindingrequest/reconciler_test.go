@@ -5,16 +5,21 @@ import (
 	"reflect"
 	"testing"
 
+	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 
+	"github.com/redhat-developer/service-binding-operator/pkg/converter"
 	"github.com/redhat-developer/service-binding-operator/test/mocks"
 )
 
@@ -55,7 +60,12 @@ func TestReconcilerReconcileError(t *testing.T) {
 
 	fakeClient := f.FakeClient()
 	fakeDynClient := f.FakeDynClient()
-	reconciler := &Reconciler{client: fakeClient, dynClient: fakeDynClient, scheme: f.S}
+	reconciler := &Reconciler{
+		client:     fakeClient,
+		dynClient:  fakeDynClient,
+		restMapper: f.FakeRESTMapper(),
+		scheme:     f.S,
+	}
 
 	res, err := reconciler.Reconcile(reconcileRequest())
 
@@ -66,6 +76,139 @@ func TestReconcilerReconcileError(t *testing.T) {
 	require.True(t, res.Requeue)
 }
 
+func TestReconcilerReconcileUnknownKind(t *testing.T) {
+	backingServiceResourceRef := "test-unknown-kind"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "reconciler",
+	}
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredServiceBindingRequest(reconcilerName, backingServiceResourceRef, "", deploymentsGVR, matchLabels)
+
+	fakeDynClient := f.FakeDynClient()
+	reconciler := &Reconciler{
+		client:    f.FakeClient(),
+		dynClient: fakeDynClient,
+		// the backing service kind is not served, since its CRD is not installed
+		restMapper: meta.NewDefaultRESTMapper(nil),
+		scheme:     f.S,
+	}
+
+	res, err := reconciler.Reconcile(reconcileRequest())
+	require.Error(t, err)
+	require.True(t, res.Requeue)
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	sbr, err := reconciler.getServiceBindingRequest(namespacedName)
+	require.NoError(t, err)
+	require.Len(t, sbr.Status.Conditions, 1)
+	require.Equal(t, UnknownKind, sbr.Status.Conditions[0].Reason)
+	require.Equal(t, corev1.ConditionFalse, sbr.Status.Conditions[0].Status)
+}
+
+func TestReconcilerReconcileBackingServiceNamespaceNotAllowed(t *testing.T) {
+	backingServiceResourceRef := "test-not-allowed"
+	backingServiceNs := "private"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "reconciler",
+	}
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredDatabaseCRD()
+	f.AddMockedUnstructuredNamespace(backingServiceNs, nil, nil)
+	f.AddMockedDatabaseCR(backingServiceResourceRef, backingServiceNs)
+	u := f.AddMockedUnstructuredServiceBindingRequest(reconcilerName, backingServiceResourceRef, "", deploymentsGVR, matchLabels)
+	require.NoError(t, unstructured.SetNestedField(u.Object, backingServiceNs, "spec", "backingServiceSelector", "namespace"))
+
+	fakeDynClient := f.FakeDynClient()
+	reconciler := &Reconciler{
+		client:     f.FakeClient(),
+		dynClient:  fakeDynClient,
+		restMapper: f.FakeRESTMapper(),
+		scheme:     f.S,
+	}
+
+	res, err := reconciler.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.True(t, res.Requeue)
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	sbr, err := reconciler.getServiceBindingRequest(namespacedName)
+	require.NoError(t, err)
+	require.Len(t, sbr.Status.Conditions, 1)
+	require.Equal(t, BackingServiceNamespaceNotAllowed, sbr.Status.Conditions[0].Reason)
+	require.Equal(t, corev1.ConditionFalse, sbr.Status.Conditions[0].Status)
+}
+
+func TestReconcilerReconcileReadAccessDenied(t *testing.T) {
+	backingServiceResourceRef := "test-read-denied"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "reconciler",
+	}
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredDatabaseCRD()
+	f.AddMockedDatabaseCR(backingServiceResourceRef, reconcilerNs)
+	u := f.AddMockedUnstructuredServiceBindingRequest(reconcilerName, backingServiceResourceRef, "", deploymentsGVR, matchLabels)
+	u.SetAnnotations(map[string]string{requesterAnnotation: `{"username":"developer"}`})
+
+	fakeDynClient := f.FakeDynClient()
+	reconciler := &Reconciler{
+		client:     f.FakeClient(),
+		dynClient:  fakeDynClient,
+		restMapper: f.FakeRESTMapper(),
+		scheme:     f.S,
+	}
+
+	res, err := reconciler.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.True(t, res.Requeue)
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	sbr, err := reconciler.getServiceBindingRequest(namespacedName)
+	require.NoError(t, err)
+	require.Len(t, sbr.Status.Conditions, 1)
+	require.Equal(t, ReadAccessDenied, sbr.Status.Conditions[0].Reason)
+	require.Equal(t, corev1.ConditionFalse, sbr.Status.Conditions[0].Status)
+	require.Len(t, sbr.Status.DeniedReads, 1)
+	require.Equal(t, backingServiceResourceRef, sbr.Status.DeniedReads[0].Name)
+}
+
+// TestReconcilerReconcileKeyCollision checks keys read from backing services of the same kind,
+// not identified by ids, are reported as colliding.
+func TestReconcilerReconcileKeyCollision(t *testing.T) {
+	f := mocks.NewFake(t, reconcilerNs)
+	sbr := f.AddMockedServiceBindingRequest(reconcilerName, nil, "", reconcilerName, deploymentsGVR, nil)
+	sbr.Spec.BackingServiceSelector = nil
+	sbr.Spec.CustomEnvVar = nil
+	sbr.Spec.BackingServiceSelectors = &[]v1alpha1.BackingServiceSelector{
+		{GroupVersionKind: v1.GroupVersionKind{Version: "v1", Kind: "Secret"}, ResourceRef: "db-credentials"},
+		{GroupVersionKind: v1.GroupVersionKind{Version: "v1", Kind: "Secret"}, ResourceRef: "db-replica-credentials"},
+	}
+	f.AddMockedSecret("db-credentials")
+	f.AddMockedSecret("db-replica-credentials")
+	f.AddMockedUnstructuredDeployment(reconcilerName, nil)
+
+	reconciler := &Reconciler{
+		client:     f.FakeClient(),
+		dynClient:  f.FakeDynClient(),
+		restMapper: f.FakeRESTMapper(),
+		scheme:     f.S,
+	}
+
+	res, err := reconciler.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.True(t, res.Requeue)
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	sbrOutput, err := reconciler.getServiceBindingRequest(namespacedName)
+	require.NoError(t, err)
+	require.Len(t, sbrOutput.Status.Conditions, 1)
+	require.Equal(t, KeyCollision, sbrOutput.Status.Conditions[0].Reason)
+	require.Equal(t, corev1.ConditionFalse, sbrOutput.Status.Conditions[0].Status)
+	require.Contains(t, sbrOutput.Status.Conditions[0].Message, "SECRET_USER")
+}
+
 // TestApplicationSelectorByName tests discovery of application by name
 func TestApplicationSelectorByName(t *testing.T) {
 	backingServiceResourceRef := "backingServiceRef"
@@ -80,7 +223,14 @@ func TestApplicationSelectorByName(t *testing.T) {
 
 	fakeClient := f.FakeClient()
 	fakeDynClient := f.FakeDynClient()
-	reconciler := &Reconciler{client: fakeClient, dynClient: fakeDynClient, scheme: f.S}
+	boundObjects := newBoundObjectIndex()
+	reconciler := &Reconciler{
+		client:       fakeClient,
+		dynClient:    fakeDynClient,
+		restMapper:   f.FakeRESTMapper(),
+		scheme:       f.S,
+		boundObjects: boundObjects,
+	}
 
 	t.Run("test-application-selector-by-name", func(t *testing.T) {
 
@@ -105,6 +255,16 @@ func TestApplicationSelectorByName(t *testing.T) {
 			},
 		}
 		require.True(t, reflect.DeepEqual(expectedStatus, sbrOutput.Status.ApplicationObjects[0]))
+
+		// backing service CR is indexed, so its status changes trigger the reconciliation
+		db := objectReference{
+			Group:     mocks.CRDName,
+			Kind:      mocks.CRDKind,
+			Namespace: reconcilerNs,
+			Name:      backingServiceResourceRef,
+		}
+		_, found := boundObjects.statusPaths(db)
+		require.True(t, found)
 	})
 }
 
@@ -127,7 +287,12 @@ func TestReconcilerReconcileUsingSecret(t *testing.T) {
 
 	fakeClient := f.FakeClient()
 	fakeDynClient := f.FakeDynClient()
-	reconciler := &Reconciler{client: fakeClient, dynClient: fakeDynClient, scheme: f.S}
+	reconciler := &Reconciler{
+		client:     fakeClient,
+		dynClient:  fakeDynClient,
+		restMapper: f.FakeRESTMapper(),
+		scheme:     f.S,
+	}
 
 	t.Run("reconcile-using-secret", func(t *testing.T) {
 		res, err := reconciler.Reconcile(reconcileRequest())
@@ -182,7 +347,12 @@ func TestReconcilerReconcileUsingVolumes(t *testing.T) {
 	f.AddMockedSecret("db-credentials")
 
 	fakeClient := f.FakeClient()
-	reconciler := &Reconciler{client: fakeClient, dynClient: f.FakeDynClient(), scheme: f.S}
+	reconciler := &Reconciler{
+		client:     fakeClient,
+		dynClient:  f.FakeDynClient(),
+		restMapper: f.FakeRESTMapper(),
+		scheme:     f.S,
+	}
 
 	t.Run("reconcile-using-volume", func(t *testing.T) {
 		res, err := reconciler.Reconcile(reconcileRequest())
@@ -203,6 +373,20 @@ func TestReconcilerReconcileUsingVolumes(t *testing.T) {
 		require.Equal(t, 1, len(volumes))
 		require.Equal(t, reconcilerName, volumes[0].Name)
 		require.Equal(t, reconcilerName, volumes[0].VolumeSource.Secret.SecretName)
+
+		// declared items are projected from the keys they were stored as in the intermediary secret
+		require.Equal(t, []corev1.KeyToPath{
+			{Key: "DATABASE_SECRET_PASSWORD", Path: "DATABASE_SECRET_PASSWORD"},
+			{Key: "DATABASE_SECRET_USER", Path: "DATABASE_SECRET_USER"},
+		}, volumes[0].VolumeSource.Secret.Items)
+
+		secret, err := reconciler.dynClient.Resource(corev1.SchemeGroupVersion.WithResource(SecretResource)).
+			Namespace(reconcilerNs).
+			Get(reconcilerName, v1.GetOptions{})
+		require.NoError(t, err)
+		for _, item := range volumes[0].VolumeSource.Secret.Items {
+			require.Contains(t, secret.Object["data"], item.Key)
+		}
 	})
 }
 
@@ -221,7 +405,12 @@ func TestReconcilerGenericBinding(t *testing.T) {
 	f.AddMockedSecret("db-credentials")
 
 	fakeClient := f.FakeClient()
-	reconciler := &Reconciler{client: fakeClient, dynClient: f.FakeDynClient(), scheme: f.S}
+	reconciler := &Reconciler{
+		client:     fakeClient,
+		dynClient:  f.FakeDynClient(),
+		restMapper: f.FakeRESTMapper(),
+		scheme:     f.S,
+	}
 
 	// Reconcile without deployment
 	res, err := reconciler.Reconcile(reconcileRequest())
@@ -240,7 +429,12 @@ func TestReconcilerGenericBinding(t *testing.T) {
 	f.AddMockedUnstructuredDeployment(reconcilerName, matchLabels)
 
 	fakeClient = f.FakeClient()
-	reconciler = &Reconciler{client: fakeClient, dynClient: f.FakeDynClient(), scheme: f.S}
+	reconciler = &Reconciler{
+		client:     fakeClient,
+		dynClient:  f.FakeDynClient(),
+		restMapper: f.FakeRESTMapper(),
+		scheme:     f.S,
+	}
 	res, err = reconciler.Reconcile(reconcileRequest())
 	require.NoError(t, err)
 	require.False(t, res.Requeue)
@@ -263,7 +457,12 @@ func TestReconcilerGenericBinding(t *testing.T) {
 	s.Data["password"] = []byte("abc123")
 	require.NoError(t, fakeClient.Update(ctx, &s))
 
-	reconciler = &Reconciler{client: fakeClient, dynClient: f.FakeDynClient(), scheme: f.S}
+	reconciler = &Reconciler{
+		client:     fakeClient,
+		dynClient:  f.FakeDynClient(),
+		restMapper: f.FakeRESTMapper(),
+		scheme:     f.S,
+	}
 	res, err = reconciler.Reconcile(reconcileRequest())
 	require.NoError(t, err)
 	require.False(t, res.Requeue)
@@ -302,7 +501,12 @@ func TestReconcilerReconcileWithConflictingAppSelc(t *testing.T) {
 
 	fakeClient := f.FakeClient()
 	fakeDynClient := f.FakeDynClient()
-	reconciler := &Reconciler{client: fakeClient, dynClient: fakeDynClient, scheme: f.S}
+	reconciler := &Reconciler{
+		client:     fakeClient,
+		dynClient:  fakeDynClient,
+		restMapper: f.FakeRESTMapper(),
+		scheme:     f.S,
+	}
 
 	t.Run("test-reconciler-reconcile-with-conflicting-application-selector", func(t *testing.T) {
 
@@ -328,6 +532,87 @@ func TestReconcilerReconcileWithConflictingAppSelc(t *testing.T) {
 		require.Equal(t, BindingSuccess, sbrOutput.Status.BindingStatus)
 		require.Equal(t, reconcilerName, sbrOutput.Status.Secret)
 		require.Equal(t, corev1.ConditionTrue, sbrOutput.Status.Conditions[0].Status)
-		require.True(t, reflect.DeepEqual(expectedStatus, sbrOutput.Status.ApplicationObjects[0]))
+		// the fake dynamic client ignores field selectors, thus all deployments are listed
+		require.Contains(t, sbrOutput.Status.ApplicationObjects, expectedStatus)
 	})
 }
+
+// TestReconcilerUnbindWithoutBackingService tests unbinding a SBR when its backing service, CRD and
+// CSV have been removed before the SBR itself.
+func TestReconcilerUnbindWithoutBackingService(t *testing.T) {
+	ctx := context.TODO()
+	backingServiceResourceRef := "test-unbind"
+	matchLabels := map[string]string{
+		"connects-to": "database",
+		"environment": "reconciler",
+	}
+	f := mocks.NewFake(t, reconcilerNs)
+	f.AddMockedUnstructuredServiceBindingRequest(reconcilerName, backingServiceResourceRef, "", deploymentsGVR, matchLabels)
+	f.AddMockedUnstructuredCSVWithVolumeMount("cluster-service-version-list")
+	crd := f.AddMockedUnstructuredDatabaseCRD()
+	f.AddMockedUnstructuredDatabaseCR(backingServiceResourceRef)
+	f.AddMockedUnstructuredDeployment(reconcilerName, matchLabels)
+	f.AddMockedSecret("db-credentials")
+
+	fakeClient := f.FakeClient()
+	fakeDynClient := f.FakeDynClient()
+	reconciler := &Reconciler{
+		client:     fakeClient,
+		dynClient:  fakeDynClient,
+		restMapper: f.FakeRESTMapper(),
+		scheme:     f.S,
+	}
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+
+	res, err := reconciler.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	// making the bound deployment visible for the dynamic client as well
+	d := appsv1.Deployment{}
+	require.NoError(t, fakeClient.Get(ctx, namespacedName, &d))
+	require.Len(t, d.Spec.Template.Spec.Containers[0].EnvFrom, 1)
+	require.Len(t, d.Spec.Template.Spec.Volumes, 1)
+	u, err := converter.ToUnstructured(&d)
+	require.NoError(t, err)
+	_, err = fakeDynClient.Resource(deploymentsGVR).Namespace(reconcilerNs).Update(u, v1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// removing backing service, its CRD and the CSV describing it
+	crGVR, _ := meta.UnsafeGuessKindToResource(
+		schema.GroupVersionKind{Group: mocks.CRDName, Version: mocks.CRDVersion, Kind: mocks.CRDKind})
+	require.NoError(t, fakeDynClient.Resource(crGVR).Namespace(reconcilerNs).
+		Delete(backingServiceResourceRef, &v1.DeleteOptions{}))
+	require.NoError(t, fakeDynClient.Resource(CRDV1beta1GVR).Namespace(crd.GetNamespace()).
+		Delete(crd.GetName(), &v1.DeleteOptions{}))
+	csvGVR := olmv1alpha1.SchemeGroupVersion.WithResource(csvResource)
+	require.NoError(t, fakeDynClient.Resource(csvGVR).Namespace(reconcilerNs).
+		Delete("cluster-service-version-list", &v1.DeleteOptions{}))
+
+	// marking the SBR for deletion
+	sbr, err := reconciler.getServiceBindingRequest(namespacedName)
+	require.NoError(t, err)
+	require.Contains(t, sbr.GetFinalizers(), Finalizer)
+	now := v1.Now()
+	sbr.SetDeletionTimestamp(&now)
+	_, err = updateServiceBindingRequest(fakeDynClient, sbr)
+	require.NoError(t, err)
+
+	res, err = reconciler.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	sbr, err = reconciler.getServiceBindingRequest(namespacedName)
+	require.NoError(t, err)
+	require.NotContains(t, sbr.GetFinalizers(), Finalizer)
+
+	d = appsv1.Deployment{}
+	require.NoError(t, fakeClient.Get(ctx, namespacedName, &d))
+	require.Empty(t, d.Spec.Template.Spec.Containers[0].EnvFrom)
+	require.Empty(t, d.Spec.Template.Spec.Containers[0].VolumeMounts)
+	require.Empty(t, d.Spec.Template.Spec.Volumes)
+
+	_, err = fakeDynClient.Resource(corev1.SchemeGroupVersion.WithResource(SecretResource)).
+		Namespace(reconcilerNs).Get(reconcilerName, v1.GetOptions{})
+	require.True(t, errors.IsNotFound(err))
+}
@@ -5,15 +5,36 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-// RelatedResource represents a SBR related resource, composed by its CR and CRDDescription.
+// RelatedResource represents a SBR related resource, composed by its CR and CRDDescription, or by
+// its CR and binding secret when the CR is a Provisioned Service. Backing services of core kinds
+// are composed by the object alone.
 type RelatedResource struct {
 	CRDDescription *v1alpha1.CRDDescription
 	CR             *unstructured.Unstructured
+	// BindingSecret is the name of the binding secret published by a Provisioned Service.
+	BindingSecret string
+	// ID identifies the backing service among the selected ones, when informed by its selector.
+	ID string
+	// EnvVarPrefix prefixes the binding keys of the backing service, when informed by its selector.
+	EnvVarPrefix *string
 }
 
 // RelatedResources contains a collection of SBR related resources.
 type RelatedResources []*RelatedResource
 
+// GetDescribedCRs returns a slice of the unstructured CRs contained in the collection which are
+// neither Provisioned Services nor of core kinds, having their binding data described by metadata
+// instead.
+func (rr RelatedResources) GetDescribedCRs() []*unstructured.Unstructured {
+	var crs []*unstructured.Unstructured
+	for _, r := range rr {
+		if r.BindingSecret == "" && !isCoreBackingService(r.CR.GroupVersionKind().GroupKind()) {
+			crs = append(crs, r.CR)
+		}
+	}
+	return crs
+}
+
 // GetCRs returns a slice of unstructured CRs contained in the collection.
 func (rr RelatedResources) GetCRs() []*unstructured.Unstructured {
 	var crs []*unstructured.Unstructured
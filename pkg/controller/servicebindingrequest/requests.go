@@ -0,0 +1,83 @@
+package servicebindingrequest
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+)
+
+// bindingRequests reads and writes the resources expressing binding intent. Those are either
+// ServiceBindingRequests, or resources translated to and from them, so all kinds are handled by the
+// same binding pipeline.
+type bindingRequests interface {
+	// GroupVersionKind returns the kind of the resources.
+	GroupVersionKind() schema.GroupVersionKind
+	// FromUnstructured translates the informed resource into a ServiceBindingRequest, returning as
+	// well the environment variables referring to binding secret keys it declares.
+	FromUnstructured(u *unstructured.Unstructured) (*v1alpha1.ServiceBindingRequest, []corev1.EnvVar, error)
+	// Get reads and translates the resource having the informed namespaced name.
+	Get(namespacedName types.NamespacedName) (*v1alpha1.ServiceBindingRequest, []corev1.EnvVar, error)
+	// Update writes the metadata of the informed translated resource, returning it updated.
+	Update(sbr *v1alpha1.ServiceBindingRequest) (*v1alpha1.ServiceBindingRequest, error)
+	// UpdateStatus writes the status of the informed translated resource, returning it updated.
+	UpdateStatus(sbr *v1alpha1.ServiceBindingRequest) (*v1alpha1.ServiceBindingRequest, error)
+}
+
+// sbrRequests reads and writes ServiceBindingRequests, which don't need translation.
+type sbrRequests struct {
+	dynClient dynamic.Interface // kubernetes dynamic api client
+}
+
+// GroupVersionKind returns the ServiceBindingRequest kind.
+func (r *sbrRequests) GroupVersionKind() schema.GroupVersionKind {
+	return v1alpha1.SchemeGroupVersion.WithKind(ServiceBindingRequestKind)
+}
+
+// FromUnstructured converts the informed ServiceBindingRequest.
+func (r *sbrRequests) FromUnstructured(
+	u *unstructured.Unstructured,
+) (*v1alpha1.ServiceBindingRequest, []corev1.EnvVar, error) {
+	sbr := &v1alpha1.ServiceBindingRequest{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, sbr); err != nil {
+		return nil, nil, err
+	}
+	return sbr, nil, nil
+}
+
+// Get reads the ServiceBindingRequest having the informed namespaced name.
+func (r *sbrRequests) Get(
+	namespacedName types.NamespacedName,
+) (*v1alpha1.ServiceBindingRequest, []corev1.EnvVar, error) {
+	u, err := r.dynClient.Resource(GroupVersion).
+		Namespace(namespacedName.Namespace).
+		Get(namespacedName.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.FromUnstructured(u)
+}
+
+// Update updates the informed ServiceBindingRequest.
+func (r *sbrRequests) Update(sbr *v1alpha1.ServiceBindingRequest) (*v1alpha1.ServiceBindingRequest, error) {
+	return updateServiceBindingRequest(r.dynClient, sbr)
+}
+
+// UpdateStatus updates the status of the informed ServiceBindingRequest.
+func (r *sbrRequests) UpdateStatus(sbr *v1alpha1.ServiceBindingRequest) (*v1alpha1.ServiceBindingRequest, error) {
+	return updateServiceBindingRequestStatus(r.dynClient, sbr)
+}
+
+// requestsOrDefault returns the informed bindingRequests, or the ServiceBindingRequests ones when
+// not informed.
+func requestsOrDefault(requests bindingRequests, dynClient dynamic.Interface) bindingRequests {
+	if requests != nil {
+		return requests
+	}
+	return &sbrRequests{dynClient: dynClient}
+}
@@ -0,0 +1,56 @@
+package servicebindingrequest
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// UnknownKindErr is returned when a kind is not served by the cluster, for instance when the CRD
+// declaring it is not installed.
+var UnknownKindErr = errors.New("kind is not served by the cluster")
+
+// crdGroupKind is the group kind of CustomResourceDefinitions, regardless of their version.
+var crdGroupKind = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+// resettableRESTMapper is a RESTMapper caching discovery information until reset.
+type resettableRESTMapper interface {
+	meta.RESTMapper
+	Reset()
+}
+
+// NewRESTMapper returns a RESTMapper resolving kinds and resources from the discovery information
+// served by the cluster, cached in memory until reset.
+func NewRESTMapper(cfg *rest.Config) (*restmapper.DeferredDiscoveryRESTMapper, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)), nil
+}
+
+// resourceForKind returns the resource serving the informed kind, or UnknownKindErr when the
+// cluster doesn't serve it.
+func resourceForKind(
+	mapper meta.RESTMapper,
+	gvk schema.GroupVersionKind,
+) (schema.GroupVersionResource, error) {
+	var versions []string
+	if gvk.Version != "" {
+		versions = append(versions, gvk.Version)
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), versions...)
+	if meta.IsNoMatchError(err) {
+		return schema.GroupVersionResource{}, fmt.Errorf("%w: %s", UnknownKindErr, err)
+	}
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	return mapping.Resource, nil
+}
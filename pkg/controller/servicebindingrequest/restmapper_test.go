@@ -0,0 +1,150 @@
+package servicebindingrequest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+// fakeResettableRESTMapper counts how many times it has been reset.
+type fakeResettableRESTMapper struct {
+	meta.RESTMapper
+	resets int
+}
+
+func (m *fakeResettableRESTMapper) Reset() {
+	m.resets++
+}
+
+func TestResourceForKind(t *testing.T) {
+	endpointsGVK := schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Endpoints"}
+	policyGVK := schema.GroupVersionKind{Group: "policy.example.com", Version: "v1", Kind: "Policy"}
+
+	mapper := meta.NewDefaultRESTMapper(
+		[]schema.GroupVersion{endpointsGVK.GroupVersion(), policyGVK.GroupVersion()})
+	mapper.AddSpecific(
+		endpointsGVK,
+		endpointsGVK.GroupVersion().WithResource("endpoints"),
+		endpointsGVK.GroupVersion().WithResource("endpoints"),
+		meta.RESTScopeNamespace,
+	)
+	mapper.AddSpecific(
+		policyGVK,
+		policyGVK.GroupVersion().WithResource("policies"),
+		policyGVK.GroupVersion().WithResource("policy"),
+		meta.RESTScopeNamespace,
+	)
+
+	t.Run("irregular plurals", func(t *testing.T) {
+		gvr, err := resourceForKind(mapper, endpointsGVK)
+		require.NoError(t, err)
+		require.Equal(t, "endpoints", gvr.Resource)
+
+		gvr, err = resourceForKind(mapper, policyGVK)
+		require.NoError(t, err)
+		require.Equal(t, "policies", gvr.Resource)
+	})
+
+	t.Run("version not informed", func(t *testing.T) {
+		gvr, err := resourceForKind(mapper, schema.GroupVersionKind{Group: policyGVK.Group, Kind: policyGVK.Kind})
+		require.NoError(t, err)
+		require.Equal(t, policyGVK.GroupVersion().WithResource("policies"), gvr)
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Unknown"}
+		_, err := resourceForKind(mapper, gvk)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, UnknownKindErr))
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		gvk := schema.GroupVersionKind{Group: policyGVK.Group, Version: "v2", Kind: policyGVK.Kind}
+		_, err := resourceForKind(mapper, gvk)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, UnknownKindErr))
+	})
+}
+
+func TestRESTMapperResetter(t *testing.T) {
+	mapper := &fakeResettableRESTMapper{RESTMapper: meta.NewDefaultRESTMapper(nil)}
+	h := NewCRDWatchEventHandler(mapper)
+
+	h.Create(event.CreateEvent{}, nil)
+	require.Equal(t, 1, mapper.resets)
+	h.Delete(event.DeleteEvent{}, nil)
+	require.Equal(t, 2, mapper.resets)
+
+	// update sends an update event from the mocked CRD to the one changed by the informed function.
+	update := func(change func(crd *apiextensionv1.CustomResourceDefinition)) {
+		crdOld := mocks.DatabaseCRDV1Mock()
+		crdNew := mocks.DatabaseCRDV1Mock()
+		change(&crdNew)
+		h.Update(event.UpdateEvent{ObjectOld: &crdOld, ObjectNew: &crdNew}, nil)
+	}
+
+	t.Run("metadata and unrelated status changes", func(t *testing.T) {
+		mapper.resets = 0
+		update(func(crd *apiextensionv1.CustomResourceDefinition) {
+			crd.SetResourceVersion("2")
+			crd.SetAnnotations(map[string]string{"example.com/annotation": "value"})
+			crd.Status.StoredVersions = []string{mocks.CRDVersion}
+			crd.Status.Conditions = []apiextensionv1.CustomResourceDefinitionCondition{{
+				Type:   apiextensionv1.NamesAccepted,
+				Status: apiextensionv1.ConditionTrue,
+			}}
+		})
+		require.Equal(t, 0, mapper.resets)
+	})
+
+	t.Run("accepted names change", func(t *testing.T) {
+		mapper.resets = 0
+		update(func(crd *apiextensionv1.CustomResourceDefinition) {
+			crd.Status.AcceptedNames = crd.Spec.Names
+		})
+		require.Equal(t, 1, mapper.resets)
+	})
+
+	t.Run("becoming established", func(t *testing.T) {
+		mapper.resets = 0
+		update(func(crd *apiextensionv1.CustomResourceDefinition) {
+			crd.Status.Conditions = []apiextensionv1.CustomResourceDefinitionCondition{{
+				Type:   apiextensionv1.Established,
+				Status: apiextensionv1.ConditionTrue,
+			}}
+		})
+		require.Equal(t, 1, mapper.resets)
+	})
+
+	t.Run("served flag changes", func(t *testing.T) {
+		mapper.resets = 0
+		update(func(crd *apiextensionv1.CustomResourceDefinition) {
+			crd.Spec.Versions[0].Served = true
+		})
+		require.Equal(t, 1, mapper.resets)
+	})
+
+	t.Run("versions change", func(t *testing.T) {
+		mapper.resets = 0
+		update(func(crd *apiextensionv1.CustomResourceDefinition) {
+			crd.Spec.Versions = append(crd.Spec.Versions,
+				apiextensionv1.CustomResourceDefinitionVersion{Name: "v2", Served: true})
+		})
+		require.Equal(t, 1, mapper.resets)
+	})
+
+	t.Run("names change", func(t *testing.T) {
+		mapper.resets = 0
+		update(func(crd *apiextensionv1.CustomResourceDefinition) {
+			crd.Spec.Names.ShortNames = []string{"db"}
+		})
+		require.Equal(t, 1, mapper.resets)
+	})
+}
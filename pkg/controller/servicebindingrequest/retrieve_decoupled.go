@@ -11,8 +11,12 @@ import (
 )
 
 // Get returns the data read from related resources (see ReadBindableResourcesData and
-// ReadCRDDescriptionData).
+// ReadCRDDescriptionData), failing when keys read from different backing services collide.
 func (r *Retriever) Get() (map[string][]byte, error) {
+	if err := r.collisionErr(); err != nil {
+		return nil, err
+	}
+
 	// interpolating custom environment
 	envParser := NewCustomEnvParser(r.plan.SBR.Spec.CustomEnvVar, r.cache)
 	customVars, err := envParser.Parse()
@@ -45,6 +49,8 @@ func (r *Retriever) ReadBindableResourcesData(
 			{Group: "", Version: "v1", Resource: "services"},
 			{Group: "route.openshift.io", Version: "v1", Resource: "routes"},
 		}, r.client)
+		// denied reads are reported along with the retriever ones
+		b.access = r.access
 
 		vals, err := b.GetBindableVariables()
 		if err != nil {
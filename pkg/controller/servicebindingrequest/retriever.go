@@ -2,7 +2,9 @@ package servicebindingrequest
 
 import (
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -10,69 +12,111 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
 )
 
 // Retriever reads all data referred in plan instance, and store in a secret.
 type Retriever struct {
-	logger        *log.Log                     // logger instance
-	data          map[string][]byte            // data retrieved
-	Objects       []*unstructured.Unstructured // list of objects employed
-	client        dynamic.Interface            // Kubernetes API client
-	plan          *Plan                        // plan instance
-	VolumeKeys    []string                     // list of keys found
-	bindingPrefix string                       // prefix for variable names
-	cache         map[string]interface{}       // store visited paths
+	logger        *log.Log                             // logger instance
+	data          map[string][]byte                    // data retrieved
+	Objects       []*unstructured.Unstructured         // list of objects employed
+	client        dynamic.Interface                    // Kubernetes API client
+	plan          *Plan                                // plan instance
+	VolumeKeys    []VolumeKey                          // list of keys projected as files
+	bindingPrefix string                               // prefix for variable names
+	bindAsFiles   bool                                 // keys are stored as they are, named as files
+	cache         map[string]interface{}               // store visited paths
+	consumable    map[string]bool                      // namespaces found to allow being consumed
+	access        *accessChecker                       // checks the requester can read backing resources
+	services      map[objectReference]*RelatedResource // related resources by backing service
+	owners        map[string]objectReference           // backing service each key was read from
+	collisions    map[string]bool                      // keys read from several backing services
 }
 
+// KeyCollisionErr is returned when binding keys read from different backing services collide.
+var KeyCollisionErr = errors.New("binding keys collide")
+
 const (
-	basePrefix              = "binding:env:object"
-	secretPrefix            = basePrefix + ":secret"
-	configMapPrefix         = basePrefix + ":configmap"
-	attributePrefix         = "binding:env:attribute"
-	volumeMountSecretPrefix = "binding:volumemount:secret"
-)
+	basePrefix                 = "binding:env:object"
+	secretPrefix               = basePrefix + ":secret"
+	configMapPrefix            = basePrefix + ":configmap"
+	attributePrefix            = "binding:env:attribute"
+	volumeMountSecretPrefix    = "binding:volumemount:secret"
+	volumeMountConfigMapPrefix = "binding:volumemount:configmap"
 
-// getNestedValue retrieve value from dotted key path
-func (r *Retriever) getNestedValue(key string, sectionMap interface{}) (string, interface{}, error) {
-	if !strings.Contains(key, ".") {
-		value, exists := sectionMap.(map[string]interface{})[key]
-		if !exists {
-			return "", sectionMap, nil
-		}
-		return fmt.Sprintf("%v", value), sectionMap, nil
-	}
-	attrs := strings.SplitN(key, ".", 2)
-	newSectionMap, exists := sectionMap.(map[string]interface{})[attrs[0]]
-	if !exists {
-		return "", newSectionMap, nil
-	}
-	return r.getNestedValue(attrs[1], newSectionMap.(map[string]interface{}))
-}
+	// allItems is the descriptor item selecting all keys of a secret or config map.
+	allItems = "*"
+)
 
-// getCRKey retrieve key in section from CR object, part of the "plan" instance.
-func (r *Retriever) getCRKey(u *unstructured.Unstructured, section string, key string) (string, interface{}, error) {
+// getCRValues retrieve the values selected by the descriptor path in section from CR object, part
+// of the "plan" instance.
+func (r *Retriever) getCRValues(u *unstructured.Unstructured, section string, path string) ([]interface{}, interface{}, error) {
 	obj := u.Object
 	objName := u.GetName()
-	log := r.logger.WithValues("CR.Name", objName, "CR.section", section, "CR.key", key)
+	log := r.logger.WithValues("CR.Name", objName, "CR.section", section, "CR.key", path)
 	log.Debug("Reading CR attributes...")
 
 	sectionMap, exists := obj[section]
 	if !exists {
-		return "", sectionMap, fmt.Errorf("Can't find '%s' section in CR named '%s'", section, objName)
+		return nil, sectionMap, fmt.Errorf("Can't find '%s' section in CR named '%s'", section, objName)
+	}
+	m, ok := sectionMap.(map[string]interface{})
+	if !ok {
+		return nil, sectionMap, fmt.Errorf("%w: '%s' section in CR named '%s' is not an object", InvalidPathErr, section, objName)
 	}
 
 	log.WithValues("SectionMap", sectionMap).Debug("Getting values from sectionmap")
-	v, _, err := r.getNestedValue(key, sectionMap)
-	for k, v := range sectionMap.(map[string]interface{}) {
+	values, err := findPathValues(m, path)
+	for k, v := range m {
 		if _, ok := r.cache[section]; !ok {
 			r.cache[section] = make(map[string]interface{})
 		}
 		r.cache[section].(map[string]interface{})[k] = v
 	}
+	return values, sectionMap, err
+}
+
+// getCRKey retrieve the single value selected by the descriptor path in section from CR object,
+// part of the "plan" instance. Paths selecting maps, slices or multiple values are errors.
+func (r *Retriever) getCRKey(u *unstructured.Unstructured, section string, key string) (string, interface{}, error) {
+	values, sectionMap, err := r.getCRValues(u, section, key)
+	if err != nil {
+		return "", sectionMap, err
+	}
+	v, err := singlePathValue(key, values)
 	return v, sectionMap, err
 }
 
+// singlePathValue returns the single scalar value selected by the informed descriptor path, or an
+// empty string when none is selected.
+func singlePathValue(path string, values []interface{}) (string, error) {
+	switch len(values) {
+	case 0:
+		return "", nil
+	case 1:
+		switch v := values[0].(type) {
+		case map[string]interface{}, []interface{}:
+			return "", fmt.Errorf("%w: '%s' selects %T, a single value was expected", InvalidPathErr, path, v)
+		case nil:
+			return "", nil
+		default:
+			return fmt.Sprintf("%v", v), nil
+		}
+	default:
+		return "", fmt.Errorf("%w: '%s' selects %d values, a single value was expected", InvalidPathErr, path, len(values))
+	}
+}
+
+// isObjectDescriptor evaluates whether the informed x-descriptor refers to a secret or config map
+// named by the path value.
+func isObjectDescriptor(xDescriptor string) bool {
+	return strings.HasPrefix(xDescriptor, secretPrefix) ||
+		strings.HasPrefix(xDescriptor, configMapPrefix) ||
+		strings.HasPrefix(xDescriptor, volumeMountSecretPrefix) ||
+		strings.HasPrefix(xDescriptor, volumeMountConfigMapPrefix)
+}
+
 // read attributes from CR, where place means which top level key name contains the "path" actual
 // value, and parsing x-descriptors in order to either directly read CR data, or read items from
 // a secret.
@@ -89,14 +133,24 @@ func (r *Retriever) read(cr *unstructured.Unstructured, place, path string, xDes
 
 	// holds the configMap name and items
 	configMaps := make(map[string][]string)
-	pathValue, _, err := r.getCRKey(cr, place, path)
+
+	// holds the secret and configMap name and the items projected as files
+	secretVolumeItems := make(map[string][]*volumeItem)
+	configMapVolumeItems := make(map[string][]*volumeItem)
+	values, _, err := r.getCRValues(cr, place, path)
 	if err != nil {
 		return err
 	}
+	// paths referring to secrets and config maps must select their name
+	pathValue, pathValueErr := singlePathValue(path, values)
 	for _, xDescriptor := range xDescriptors {
 		log = log.WithValues("CRDDescription.xDescriptor", xDescriptor, "cache", r.cache)
 		log.Debug("Inspecting xDescriptor...")
 
+		if pathValueErr != nil && isObjectDescriptor(xDescriptor) {
+			return pathValueErr
+		}
+
 		if _, ok := r.cache[place].(map[string]interface{}); !ok {
 			r.cache[place] = make(map[string]interface{})
 		}
@@ -110,11 +164,26 @@ func (r *Retriever) read(cr *unstructured.Unstructured, place, path string, xDes
 			configMaps[pathValue] = append(configMaps[pathValue], r.extractConfigMapItemName(xDescriptor))
 			r.markVisitedPaths(r.extractConfigMapItemName(xDescriptor), pathValue, place)
 		} else if strings.HasPrefix(xDescriptor, volumeMountSecretPrefix) {
-			secrets[pathValue] = append(secrets[pathValue], r.extractSecretItemName(xDescriptor))
-			r.markVisitedPaths(r.extractSecretItemName(xDescriptor), pathValue, place)
-			r.VolumeKeys = append(r.VolumeKeys, pathValue)
+			item, err := parseVolumeItem(r.extractVolumeMountItemName(xDescriptor, volumeMountSecretPrefix))
+			if err != nil {
+				return err
+			}
+			secrets[pathValue] = append(secrets[pathValue], item.key)
+			secretVolumeItems[pathValue] = append(secretVolumeItems[pathValue], item)
+			r.markVisitedPaths(item.key, pathValue, place)
+		} else if strings.HasPrefix(xDescriptor, volumeMountConfigMapPrefix) {
+			item, err := parseVolumeItem(r.extractVolumeMountItemName(xDescriptor, volumeMountConfigMapPrefix))
+			if err != nil {
+				return err
+			}
+			configMaps[pathValue] = append(configMaps[pathValue], item.key)
+			configMapVolumeItems[pathValue] = append(configMapVolumeItems[pathValue], item)
+			r.markVisitedPaths(item.key, pathValue, place)
 		} else if strings.HasPrefix(xDescriptor, attributePrefix) {
-			r.store(cr, path, []byte(pathValue))
+			// maps and slices are flattened into a key per entry
+			for k, v := range flattenValues(pathKey(path), values) {
+				r.store(cr, k, []byte(v))
+			}
 		} else {
 			log.Debug("Defaulting....")
 		}
@@ -122,17 +191,19 @@ func (r *Retriever) read(cr *unstructured.Unstructured, place, path string, xDes
 
 	for name, items := range secrets {
 		// loading secret items all-at-once
-		err := r.readSecret(cr, name, items, place, path)
+		stored, err := r.readSecret(cr, name, items, place, path)
 		if err != nil {
 			return err
 		}
+		r.VolumeKeys = appendVolumeKeys(r.VolumeKeys, volumeKeys(secretVolumeItems[name], stored))
 	}
 	for name, items := range configMaps {
 		// add the function readConfigMap
-		err := r.readConfigMap(cr, name, items, place, path)
+		stored, err := r.readConfigMap(cr, name, items, place, path)
 		if err != nil {
 			return err
 		}
+		r.VolumeKeys = appendVolumeKeys(r.VolumeKeys, volumeKeys(configMapVolumeItems[name], stored))
 	}
 	return nil
 }
@@ -149,6 +220,16 @@ func (r *Retriever) extractConfigMapItemName(xDescriptor string) string {
 	return strings.ReplaceAll(xDescriptor, fmt.Sprintf("%s:", configMapPrefix), "")
 }
 
+// extractVolumeMountItemName based in volume mount x-descriptor entry, removing the informed prefix
+// in order to keep only the item, all items when none is declared.
+func (r *Retriever) extractVolumeMountItemName(xDescriptor string, prefix string) string {
+	item := strings.TrimPrefix(strings.TrimPrefix(xDescriptor, prefix), ":")
+	if item == "" {
+		return allItems
+	}
+	return item
+}
+
 // markVisitedPaths updates all visited paths in cache, This initializes the cache map
 func (r *Retriever) markVisitedPaths(name, keyPath, fromPath string) {
 	if _, ok := r.cache[fromPath]; !ok {
@@ -166,106 +247,291 @@ func (r *Retriever) markVisitedPaths(name, keyPath, fromPath string) {
 }
 
 // readSecret based in secret name and list of items, read a secret from the same namespace informed
-// in plan instance.
-func (r *Retriever) readSecret(cr *unstructured.Unstructured, name string, items []string, fromPath string, path string) error {
+// in plan instance. It returns the intermediary secret keys the bound items were stored as.
+func (r *Retriever) readSecret(
+	cr *unstructured.Unstructured,
+	name string,
+	items []string,
+	fromPath string,
+	path string,
+) (map[string]string, error) {
 	log := r.logger.WithValues("Secret.Name", name, "Secret.Items", items)
 	log.Debug("Reading secret items...")
 
+	if err := r.checkNamespace(cr.GetNamespace()); err != nil {
+		return nil, err
+	}
 	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}
+	if allowed, err := r.access.canRead("get", gvr, cr.GetNamespace(), name); err != nil || !allowed {
+		log.Debug("Skipping secret the requester can't read")
+		return map[string]string{}, err
+	}
 	secret, err := r.client.Resource(gvr).Namespace(cr.GetNamespace()).Get(name, metav1.GetOptions{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	data, exists, err := unstructured.NestedMap(secret.Object, []string{"data"}...)
+	encoded, exists, err := unstructured.NestedMap(secret.Object, []string{"data"}...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !exists {
-		return fmt.Errorf("could not find 'data' in secret")
+		return nil, fmt.Errorf("could not find 'data' in secret")
 	}
 
-	for k, v := range data {
-		value := v.(string)
-		data, err := base64.StdEncoding.DecodeString(value)
-		if err != nil {
-			return err
+	// values are kept as bytes, since secrets may hold binary data such as keystores
+	data := make(map[string][]byte, len(encoded))
+	for k, v := range encoded {
+		value, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected value of key '%s' in secret '%s'", k, name)
+		}
+		if data[k], err = base64.StdEncoding.DecodeString(value); err != nil {
+			return nil, err
 		}
-		log = log.WithValues("Secret.Key.Name", k, "Secret.Key.Length", len(data))
-		log.Debug("Inspecting secret key...")
-		r.markVisitedPaths(path, k, fromPath)
-		// update cache after reading configmap/secret in cache
-		r.cache[fromPath].(map[string]interface{})[path].(map[string]interface{})[k] = string(data)
-		// making sure key name has a secret reference
-		r.store(cr, fmt.Sprintf("configMap_%s", k), data)
-		r.store(cr, fmt.Sprintf("secret_%s", k), data)
 	}
 
+	stored := r.storeItems(cr, "secret", items, data, fromPath, path)
 	r.Objects = append(r.Objects, secret)
-	return nil
+	return stored, nil
 }
 
 // readConfigMap based in configMap name and list of items, read a configMap from the same namespace informed
-// in plan instance.
-func (r *Retriever) readConfigMap(cr *unstructured.Unstructured, name string, items []string, fromPath string, path string) error {
+// in plan instance. It returns the intermediary secret keys the bound items were stored as.
+func (r *Retriever) readConfigMap(
+	cr *unstructured.Unstructured,
+	name string,
+	items []string,
+	fromPath string,
+	path string,
+) (map[string]string, error) {
 	log := r.logger.WithValues("ConfigMap.Name", name, "ConfigMap.Items", items)
 	log.Debug("Reading ConfigMap items...")
 
+	if err := r.checkNamespace(cr.GetNamespace()); err != nil {
+		return nil, err
+	}
 	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}
+	if allowed, err := r.access.canRead("get", gvr, cr.GetNamespace(), name); err != nil || !allowed {
+		log.Debug("Skipping config map the requester can't read")
+		return map[string]string{}, err
+	}
 	u, err := r.client.Resource(gvr).Namespace(cr.GetNamespace()).Get(name, metav1.GetOptions{})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	data, exists, err := unstructured.NestedMap(u.Object, []string{"data"}...)
+	_, dataExists := u.Object["data"]
+	_, binaryDataExists := u.Object["binaryData"]
+	if !dataExists && !binaryDataExists {
+		return nil, fmt.Errorf("could not find 'data' in configMap")
+	}
+	data, err := configMapData(u)
 	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Inspecting configMap data...")
+	stored := r.storeItems(cr, "configMap", items, data, fromPath, path)
+	r.Objects = append(r.Objects, u)
+	return stored, nil
+}
+
+// DeniedReads returns the reads denied to the user requesting the SBR, whose resources were skipped.
+func (r *Retriever) DeniedReads() []v1alpha1.DeniedRead {
+	return r.access.denied
+}
+
+// checkNamespace returns an error when the informed namespace doesn't allow the plan namespace to
+// consume its resources, remembering the namespaces allowing it.
+func (r *Retriever) checkNamespace(ns string) error {
+	if r.consumable[ns] {
+		return nil
+	}
+	if err := checkConsumerNamespace(r.client, ns, r.plan.Ns); err != nil {
 		return err
 	}
-	if !exists {
-		return fmt.Errorf("could not find 'data' in secret")
+	r.consumable[ns] = true
+	return nil
+}
+
+// configMapData returns the data of the informed config map, including the binary data.
+func configMapData(u *unstructured.Unstructured) (map[string][]byte, error) {
+	data, _, err := unstructured.NestedStringMap(u.Object, "data")
+	if err != nil {
+		return nil, err
+	}
+	binaryData, _, err := unstructured.NestedStringMap(u.Object, "binaryData")
+	if err != nil {
+		return nil, err
 	}
 
-	log.Debug("Inspecting configMap data...")
+	result := make(map[string][]byte, len(data)+len(binaryData))
 	for k, v := range data {
-		value := v.(string)
-		log.Debug("Inspecting configMap key...",
-			"configMap.Key.Name", k,
-			"configMap.Key.Length", len(value),
-		)
+		result[k] = []byte(v)
+	}
+	for k, v := range binaryData {
+		if result[k], err = base64.StdEncoding.DecodeString(v); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// storeItems caches all keys of the informed secret or config map data, and stores the bound
+// items, having their key names prefixed by the informed kind. It returns the intermediary secret
+// keys the bound items were stored as.
+func (r *Retriever) storeItems(
+	cr *unstructured.Unstructured,
+	kind string,
+	items []string,
+	data map[string][]byte,
+	fromPath string,
+	path string,
+) map[string]string {
+	stored := make(map[string]string)
+	bound := boundItems(items, data)
+	for k, v := range data {
+		r.logger.Debug("Inspecting key...", "Key.Name", k, "Key.Length", len(v))
 		r.markVisitedPaths(path, k, fromPath)
-		// update cache after reading configmap/secret in cache
-		r.cache[fromPath].(map[string]interface{})[path].(map[string]interface{})[k] = value
-		// making sure key name has a configMap reference
-		r.store(cr, fmt.Sprintf("configMap_%s", k), []byte(value))
+		// update cache after reading configmap/secret in cache, custom environment variables may
+		// refer to any key
+		r.cache[fromPath].(map[string]interface{})[path].(map[string]interface{})[k] = string(v)
+		// only declared items are bound, making sure key name has a secret or configMap reference
+		if boundName, ok := bound[k]; ok {
+			key := fmt.Sprintf("%s_%s", kind, boundName)
+			if r.bindAsFiles {
+				key = boundName
+			}
+			stored[k] = r.store(cr, key, v)
+		}
 	}
+	return stored
+}
 
-	r.Objects = append(r.Objects, u)
-	return nil
+// boundItems returns the keys of the informed secret or config map data to be bound, mapped to the
+// name they're bound as, according to the items declared by descriptors. Items are either keys,
+// optionally renamed as in "password=db_password", or "*" selecting all keys.
+func boundItems(items []string, data map[string][]byte) map[string]string {
+	bound := make(map[string]string)
+	for _, item := range items {
+		if item == allItems {
+			for k := range data {
+				if _, ok := bound[k]; !ok {
+					bound[k] = k
+				}
+			}
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) == 2 && parts[1] != "" {
+			bound[parts[0]] = parts[1]
+		} else {
+			bound[parts[0]] = parts[0]
+		}
+	}
+	return bound
+}
+
+// service returns the related resource of the informed backing service, nil when not planned.
+func (r *Retriever) service(u *unstructured.Unstructured) *RelatedResource {
+	return r.services[objectReferenceFor(u.GroupVersionKind(), u)]
+}
+
+// keyPrefix returns the prefix of the keys read from the informed backing service, either the one
+// informed by its selector, or the SBR prefix followed by its id, or its kind when not identified.
+// When binding as files, keys are only prefixed by the id or the prefix informed by the selector.
+func (r *Retriever) keyPrefix(u *unstructured.Unstructured) string {
+	s := r.service(u)
+	if s != nil && s.EnvVarPrefix != nil {
+		return *s.EnvVarPrefix
+	}
+	if r.bindAsFiles {
+		if s != nil {
+			return s.ID
+		}
+		return ""
+	}
+	name := u.GetKind()
+	if s != nil && s.ID != "" {
+		name = s.ID
+	}
+	if r.bindingPrefix == "" {
+		return name
+	}
+	return fmt.Sprintf("%s_%s", r.bindingPrefix, name)
 }
 
-// store key and value, formatting key to look like an environment variable.
-func (r *Retriever) store(u *unstructured.Unstructured, key string, value []byte) {
+// store key and value, formatting key to look like an environment variable namespaced by the
+// backing service, or as it is when binding as files, since binding libraries look for files named
+// after the keys. It returns the formatted key.
+func (r *Retriever) store(u *unstructured.Unstructured, key string, value []byte) string {
+	if r.bindAsFiles {
+		if prefix := r.keyPrefix(u); prefix != "" {
+			key = fmt.Sprintf("%s_%s", prefix, key)
+		}
+		r.put(u, key, value)
+		return key
+	}
 	key = strings.ReplaceAll(key, ":", "_")
 	key = strings.ReplaceAll(key, ".", "_")
-	if r.bindingPrefix == "" {
-		key = fmt.Sprintf("%s_%s", u.GetKind(), key)
-	} else {
-		key = fmt.Sprintf("%s_%s_%s", r.bindingPrefix, u.GetKind(), key)
+	if prefix := r.keyPrefix(u); prefix != "" {
+		key = fmt.Sprintf("%s_%s", prefix, key)
 	}
 	key = strings.ToUpper(key)
+	r.put(u, key, value)
+	return key
+}
+
+// put stores the value read from the informed backing service as is, unless another backing
+// service stored the same key already, in which case the collision is recorded instead.
+func (r *Retriever) put(u *unstructured.Unstructured, key string, value []byte) {
+	ref := objectReferenceFor(u.GroupVersionKind(), u)
+	if owner, found := r.owners[key]; found && owner != ref {
+		r.logger.Info("Skipping key already read from another backing service",
+			"Key", key, "Kind", ref.Kind, "Name", ref.Name, "Owner.Kind", owner.Kind, "Owner.Name", owner.Name)
+		r.collisions[fmt.Sprintf("'%s' of %s '%s/%s' collides with %s '%s/%s'",
+			key, ref.Kind, ref.Namespace, ref.Name, owner.Kind, owner.Namespace, owner.Name)] = true
+		return
+	}
+	r.owners[key] = ref
 	r.data[key] = value
 }
 
+// collisionErr returns an error listing the keys read from more than one backing service, nil when
+// none collides.
+func (r *Retriever) collisionErr() error {
+	if len(r.collisions) == 0 {
+		return nil
+	}
+	collisions := make([]string, 0, len(r.collisions))
+	for c := range r.collisions {
+		collisions = append(collisions, c)
+	}
+	sort.Strings(collisions)
+	return fmt.Errorf("%w: %s", KeyCollisionErr, strings.Join(collisions, ", "))
+}
+
 // NewRetriever instantiate a new retriever instance.
 func NewRetriever(client dynamic.Interface, plan *Plan, bindingPrefix string) *Retriever {
+	services := make(map[objectReference]*RelatedResource)
+	for _, r := range plan.RelatedResources {
+		services[objectReferenceFor(r.CR.GroupVersionKind(), r.CR)] = r
+	}
 	return &Retriever{
 		logger:        log.NewLog("retriever"),
 		data:          make(map[string][]byte),
 		Objects:       []*unstructured.Unstructured{},
 		client:        client,
 		plan:          plan,
-		VolumeKeys:    []string{},
+		VolumeKeys:    []VolumeKey{},
 		bindingPrefix: bindingPrefix,
+		bindAsFiles:   plan.SBR.Spec.BindAsFiles,
 		cache:         make(map[string]interface{}),
+		consumable:    make(map[string]bool),
+		access:        newAccessChecker(client, &plan.SBR),
+		services:      services,
+		owners:        make(map[string]objectReference),
+		collisions:    make(map[string]bool),
 	}
 }
@@ -1,14 +1,24 @@
 package servicebindingrequest
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/converter"
 	"github.com/redhat-developer/service-binding-operator/test/mocks"
 )
 
+// retrieverSBR returns the SBR the retriever reads backing services for, in the informed namespace.
+func retrieverSBR(ns string) v1alpha1.ServiceBindingRequest {
+	return v1alpha1.ServiceBindingRequest{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "retriever"}}
+}
+
 func TestRetriever(t *testing.T) {
 	logf.SetLogger(logf.ZapLogger(true))
 	var retriever *Retriever
@@ -20,6 +30,7 @@ func TestRetriever(t *testing.T) {
 	f := mocks.NewFake(t, ns)
 	f.AddMockedUnstructuredCSV("csv")
 	f.AddNamespacedMockedSecret("db-credentials", backingServiceNs)
+	f.AddMockedUnstructuredNamespace(backingServiceNs, nil, map[string]string{allowConsumersFromAnnotation: ns})
 
 	crdDescription := mocks.CRDDescriptionMock()
 	cr, err := mocks.UnstructuredDatabaseCRMock(backingServiceNs, crName)
@@ -31,6 +42,7 @@ func TestRetriever(t *testing.T) {
 	plan := &Plan{
 		Ns:   ns,
 		Name: "retriever",
+		SBR:  retrieverSBR(ns),
 		RelatedResources: []*RelatedResource{
 			{
 				CRDDescription: &crdDescription,
@@ -43,7 +55,10 @@ func TestRetriever(t *testing.T) {
 		},
 	}
 
+	// the backing service in the other namespace is read on behalf of the requester
+	setRequester(t, &plan.SBR, "tenant")
 	fakeDynClient := f.FakeDynClient()
+	allowReads(fakeDynClient, "tenant")
 
 	retriever = NewRetriever(fakeDynClient, plan, "SERVICE_BINDING")
 	require.NotNil(t, retriever)
@@ -85,13 +100,36 @@ func TestRetriever(t *testing.T) {
 	t.Run("readSecret", func(t *testing.T) {
 		retriever.data = make(map[string][]byte)
 
-		err := retriever.readSecret(cr, "db-credentials", []string{"user", "password"}, "spec", "dbConfigMap")
+		_, err := retriever.readSecret(cr, "db-credentials", []string{"user", "password"}, "spec", "dbConfigMap")
 		require.NoError(t, err)
 
 		require.Contains(t, retriever.data, "SERVICE_BINDING_DATABASE_SECRET_USER")
 		require.Contains(t, retriever.data, "SERVICE_BINDING_DATABASE_SECRET_PASSWORD")
 	})
 
+	t.Run("readSecret declared items", func(t *testing.T) {
+		retriever.data = make(map[string][]byte)
+
+		_, err := retriever.readSecret(cr, "db-credentials", []string{"user=username"}, "status", "dbCredentials")
+		require.NoError(t, err)
+
+		require.Equal(t, map[string][]byte{
+			"SERVICE_BINDING_DATABASE_SECRET_USERNAME": []byte("user"),
+		}, retriever.data)
+	})
+
+	t.Run("readSecret all items", func(t *testing.T) {
+		retriever.data = make(map[string][]byte)
+
+		_, err := retriever.readSecret(cr, "db-credentials", []string{allItems}, "status", "dbCredentials")
+		require.NoError(t, err)
+
+		require.Equal(t, map[string][]byte{
+			"SERVICE_BINDING_DATABASE_SECRET_USER":     []byte("user"),
+			"SERVICE_BINDING_DATABASE_SECRET_PASSWORD": []byte("password"),
+		}, retriever.data)
+	})
+
 	t.Run("store", func(t *testing.T) {
 		retriever.store(cr, "test", []byte("test"))
 		require.Contains(t, retriever.data, "SERVICE_BINDING_DATABASE_TEST")
@@ -103,12 +141,105 @@ func TestRetriever(t *testing.T) {
 		require.NotNil(t, retriever)
 		retriever.data = make(map[string][]byte)
 
-		err := retriever.readSecret(cr, "db-credentials", []string{"user", "password"}, "spec", "dbConfigMap")
+		_, err := retriever.readSecret(cr, "db-credentials", []string{"user", "password"}, "spec", "dbConfigMap")
 		require.NoError(t, err)
 
 		require.Contains(t, retriever.data, "DATABASE_SECRET_USER")
 		require.Contains(t, retriever.data, "DATABASE_SECRET_PASSWORD")
 	})
+
+	t.Run("namespace not allowed", func(t *testing.T) {
+		otherPlan := *plan
+		otherPlan.Ns = "other"
+		retriever = NewRetriever(fakeDynClient, &otherPlan, "")
+
+		_, err := retriever.readSecret(cr, "db-credentials", []string{"user", "password"}, "spec", "dbConfigMap")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, BackingServiceNamespaceNotAllowedErr))
+		require.Empty(t, retriever.data)
+	})
+
+	t.Run("read denied to the requester", func(t *testing.T) {
+		deniedPlan := *plan
+		setRequester(t, &deniedPlan.SBR, "developer")
+		retriever = NewRetriever(fakeDynClient, &deniedPlan, "")
+
+		_, err := retriever.readSecret(cr, "db-credentials", []string{"user", "password"}, "spec", "dbConfigMap")
+		require.NoError(t, err)
+		require.Empty(t, retriever.data)
+		require.Len(t, retriever.DeniedReads(), 1)
+		require.Equal(t, "secrets", retriever.DeniedReads()[0].Resource)
+		require.Equal(t, "db-credentials", retriever.DeniedReads()[0].Name)
+	})
+}
+
+// TestRetrieverServiceKeys checks keys are namespaced by the backing service id or prefix, and keys
+// read from different backing services colliding are reported instead of overwritten.
+func TestRetrieverServiceKeys(t *testing.T) {
+	ns := "testing"
+	f := mocks.NewFake(t, ns)
+	f.AddMockedSecret("db-binding")
+
+	// database returns a backing service CR named as informed
+	database := func(name string) *unstructured.Unstructured {
+		cr, err := mocks.UnstructuredDatabaseCRMock(ns, name)
+		require.NoError(t, err)
+		return cr
+	}
+	primary, replica, unprefixed := database("primary"), database("replica"), database("unprefixed")
+	first, second := database("first"), database("second")
+	provisioned, err := mocks.UnstructuredProvisionedServiceMock(ns, "provisioned", "db-binding")
+	require.NoError(t, err)
+
+	replicaPrefix, emptyPrefix := "REPLICA", ""
+	plan := &Plan{
+		Ns:   ns,
+		Name: "retriever",
+		SBR:  retrieverSBR(ns),
+		RelatedResources: []*RelatedResource{
+			{CR: primary, ID: "primary"},
+			{CR: replica, ID: "replica", EnvVarPrefix: &replicaPrefix},
+			{CR: unprefixed, EnvVarPrefix: &emptyPrefix},
+			{CR: first},
+			{CR: second},
+			{CR: provisioned, BindingSecret: "db-binding", ID: "cache"},
+		},
+	}
+	retriever := NewRetriever(f.FakeDynClient(), plan, "SERVICE_BINDING")
+
+	t.Run("id", func(t *testing.T) {
+		require.Equal(t, "SERVICE_BINDING_PRIMARY_HOST", retriever.store(primary, "host", []byte("primary")))
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		require.Equal(t, "REPLICA_HOST", retriever.store(replica, "host", []byte("replica")))
+		require.Equal(t, "HOST", retriever.store(unprefixed, "host", []byte("unprefixed")))
+	})
+
+	t.Run("provisioned service id", func(t *testing.T) {
+		require.NoError(t, retriever.ReadBindingSecret(provisioned, "db-binding"))
+		require.Equal(t, []byte("user"), retriever.data["SERVICE_BINDING_CACHE_USER"])
+		require.Equal(t, []byte("password"), retriever.data["SERVICE_BINDING_CACHE_PASSWORD"])
+	})
+
+	t.Run("no collision", func(t *testing.T) {
+		data, err := retriever.Get()
+		require.NoError(t, err)
+		require.Equal(t, []byte("primary"), data["SERVICE_BINDING_PRIMARY_HOST"])
+		require.Equal(t, []byte("replica"), data["REPLICA_HOST"])
+		require.Equal(t, []byte("unprefixed"), data["HOST"])
+	})
+
+	t.Run("collision", func(t *testing.T) {
+		key := retriever.store(first, "host", []byte("first"))
+		require.Equal(t, key, retriever.store(second, "host", []byte("second")))
+		require.Equal(t, []byte("first"), retriever.data[key])
+
+		_, err := retriever.Get()
+		require.Error(t, err)
+		require.True(t, errors.Is(err, KeyCollisionErr))
+		require.Contains(t, err.Error(), "'SERVICE_BINDING_DATABASE_HOST' of Database 'testing/second'")
+	})
 }
 
 func TestRetrieverWithNestedCRKey(t *testing.T) {
@@ -129,6 +260,7 @@ func TestRetrieverWithNestedCRKey(t *testing.T) {
 	plan := &Plan{
 		Ns:   ns,
 		Name: "retriever",
+		SBR:  retrieverSBR(ns),
 		RelatedResources: []*RelatedResource{
 			{
 				CRDDescription: &crdDescription,
@@ -182,6 +314,7 @@ func TestRetrieverWithConfigMap(t *testing.T) {
 	plan := &Plan{
 		Ns:   ns,
 		Name: "retriever",
+		SBR:  retrieverSBR(ns),
 		RelatedResources: []*RelatedResource{
 			{
 				CRDDescription: &crdDescription,
@@ -216,10 +349,111 @@ func TestRetrieverWithConfigMap(t *testing.T) {
 	t.Run("readConfigMap", func(t *testing.T) {
 		retriever.data = make(map[string][]byte)
 
-		err := retriever.readConfigMap(cr, crName, []string{"user", "password"}, "spec", "dbConfigMap")
+		_, err := retriever.readConfigMap(cr, crName, []string{"user", "password"}, "spec", "dbConfigMap")
 		require.NoError(t, err)
 
 		require.Contains(t, retriever.data, ("SERVICE_BINDING_DATABASE_CONFIGMAP_USER"))
 		require.Contains(t, retriever.data, ("SERVICE_BINDING_DATABASE_CONFIGMAP_PASSWORD"))
 	})
+
+	t.Run("readConfigMap declared items", func(t *testing.T) {
+		retriever.data = make(map[string][]byte)
+
+		_, err := retriever.readConfigMap(cr, crName, []string{"password"}, "spec", "dbConfigMap")
+		require.NoError(t, err)
+
+		require.Len(t, retriever.data, 1)
+		require.Contains(t, retriever.data, "SERVICE_BINDING_DATABASE_CONFIGMAP_PASSWORD")
+	})
+}
+
+func TestRetrieverWithJSONPath(t *testing.T) {
+	ns := "testing"
+	f := mocks.NewFake(t, ns)
+	f.AddMockedSecret("db-credentials")
+
+	cr, err := mocks.UnstructuredDatabaseCRMock(ns, "db-testing")
+	require.NoError(t, err)
+	cr.Object["status"] = map[string]interface{}{
+		"endpoints": []interface{}{
+			map[string]interface{}{"name": "primary", "host": "db-0", "credentials": "db-credentials"},
+			map[string]interface{}{"name": "replica", "host": "db-1"},
+		},
+		"connection": map[string]interface{}{"host": "db", "port": int64(5432)},
+	}
+	plan := &Plan{Ns: ns, Name: "retriever", SBR: retrieverSBR(ns), RelatedResources: []*RelatedResource{{CR: cr}}}
+	retriever := NewRetriever(f.FakeDynClient(), plan, "")
+
+	t.Run("attributes", func(t *testing.T) {
+		for _, path := range []string{"endpoints.1.host", "connection", `endpoints[*].name`} {
+			require.NoError(t, retriever.read(cr, "status", path, []string{"binding:env:attribute"}))
+		}
+		require.Equal(t, []byte("db-1"), retriever.data["DATABASE_ENDPOINTS_1_HOST"])
+		require.Equal(t, []byte("db"), retriever.data["DATABASE_CONNECTION_HOST"])
+		require.Equal(t, []byte("5432"), retriever.data["DATABASE_CONNECTION_PORT"])
+		require.Equal(t, []byte("primary"), retriever.data["DATABASE_ENDPOINTS_NAME_0"])
+		require.Equal(t, []byte("replica"), retriever.data["DATABASE_ENDPOINTS_NAME_1"])
+	})
+
+	t.Run("secret", func(t *testing.T) {
+		err := retriever.read(cr, "status", `endpoints[?(@.name=="primary")].credentials`, []string{
+			"binding:env:object:secret:user",
+		})
+		require.NoError(t, err)
+		require.Contains(t, retriever.data, "DATABASE_SECRET_USER")
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		err := retriever.read(cr, "status", "connection", []string{"binding:env:object:secret:user"})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, InvalidPathErr))
+
+		err = retriever.read(cr, "status", "connection[0]", []string{"binding:env:attribute"})
+		require.Error(t, err)
+		require.True(t, errors.Is(err, InvalidPathErr))
+	})
+}
+
+func TestRetrieverWithVolumeMounts(t *testing.T) {
+	ns := "testing"
+	f := mocks.NewFake(t, ns)
+	f.AddMockedSecret("db-credentials")
+	certs := mocks.ConfigMapMock(ns, "db-certs")
+	certs.Data = map[string]string{"ca.crt": "ca"}
+	certs.BinaryData = map[string][]byte{"truststore.jks": {0xfe, 0xed, 0xfe, 0xed, 0x00}}
+	u, err := converter.ToUnstructured(certs)
+	require.NoError(t, err)
+	f.AddMockResource(u)
+
+	cr, err := mocks.UnstructuredDatabaseCRMock(ns, "db-testing")
+	require.NoError(t, err)
+	cr.Object["status"] = map[string]interface{}{"credentials": "db-credentials", "certificates": "db-certs"}
+	plan := &Plan{Ns: ns, Name: "retriever", SBR: retrieverSBR(ns), RelatedResources: []*RelatedResource{{CR: cr}}}
+	retriever := NewRetriever(f.FakeDynClient(), plan, "")
+
+	mode := int32(0400)
+	require.NoError(t, retriever.read(cr, "status", "credentials", []string{
+		"binding:volumemount:secret:user",
+		"binding:volumemount:secret:password=db/password;mode=0400",
+		"binding:volumemount:secret:missing",
+	}))
+	require.NoError(t, retriever.read(cr, "status", "certificates", []string{
+		"binding:volumemount:configmap",
+	}))
+
+	// the volume refers only to keys present in the intermediary secret
+	require.ElementsMatch(t, []VolumeKey{
+		{Key: "DATABASE_SECRET_USER", Path: "DATABASE_SECRET_USER"},
+		{Key: "DATABASE_SECRET_PASSWORD", Path: "db/password", Mode: &mode},
+		{Key: "DATABASE_CONFIGMAP_CA_CRT", Path: "DATABASE_CONFIGMAP_CA_CRT"},
+		{Key: "DATABASE_CONFIGMAP_TRUSTSTORE_JKS", Path: "DATABASE_CONFIGMAP_TRUSTSTORE_JKS"},
+	}, retriever.VolumeKeys)
+	for _, k := range retriever.VolumeKeys {
+		require.Contains(t, retriever.data, k.Key)
+	}
+	require.Equal(t, []byte{0xfe, 0xed, 0xfe, 0xed, 0x00}, retriever.data["DATABASE_CONFIGMAP_TRUSTSTORE_JKS"])
+
+	err = retriever.read(cr, "status", "credentials", []string{"binding:volumemount:secret:user=/etc/user"})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, InvalidVolumeItemErr))
 }
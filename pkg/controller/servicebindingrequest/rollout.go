@@ -0,0 +1,137 @@
+package servicebindingrequest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+)
+
+// bindingHashAnnotation is the pod template annotation holding the hash of the binding data per
+// service binding request; it only changes when the data does, triggering a new rollout.
+const bindingHashAnnotation = "service-binding-operator.apps.openshift.io/binding-hash"
+
+// podAnnotationsPath logical path to find pod template annotations on workloads having a pod template
+var podAnnotationsPath = []string{"spec", "template", "metadata", "annotations"}
+
+// hashData returns a hash of the informed data, not depending on the order keys are informed.
+func hashData(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		// lengths are written to avoid different keys and values resulting in the same content
+		_, _ = fmt.Fprintf(h, "%d:%s:%d:", len(k), k, len(data[k]))
+		_, _ = h.Write(data[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// restartStrategy returns the restart strategy informed in the SBR, or the default one.
+func (b *Binder) restartStrategy() v1alpha1.RestartStrategy {
+	if b.sbr.Spec.RestartStrategy == "" {
+		return v1alpha1.RestartStrategyHashAnnotation
+	}
+	return b.sbr.Spec.RestartStrategy
+}
+
+// readBindingHashes returns the binding hashes found in the pod annotations of the informed object,
+// in the informed path, keyed by SBR namespaced name, and the pod annotations themselves.
+func readBindingHashes(
+	obj *unstructured.Unstructured,
+	podAnnotationsPath []string,
+) (map[string]string, map[string]string, error) {
+	annotations, _, err := unstructured.NestedStringMap(obj.Object, podAnnotationsPath...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make(map[string]string)
+	if value, ok := annotations[bindingHashAnnotation]; ok && value != "" {
+		if err = json.Unmarshal([]byte(value), &hashes); err != nil {
+			return nil, nil, fmt.Errorf("unable to parse annotation '%s': %s", bindingHashAnnotation, err)
+		}
+	}
+	return hashes, annotations, nil
+}
+
+// updateBindingHashes applies fn on the binding hashes found in the pod annotations of the informed
+// object, in the informed path, keyed by SBR namespaced name, storing them back afterwards.
+func updateBindingHashes(
+	obj *unstructured.Unstructured,
+	podAnnotationsPath []string,
+	fn func(hashes map[string]string),
+) error {
+	hashes, annotations, err := readBindingHashes(obj, podAnnotationsPath)
+	if err != nil {
+		return err
+	}
+
+	fn(hashes)
+
+	if len(hashes) == 0 {
+		delete(annotations, bindingHashAnnotation)
+	} else {
+		data, err := json.Marshal(hashes)
+		if err != nil {
+			return err
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[bindingHashAnnotation] = string(data)
+	}
+
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(obj.Object, podAnnotationsPath...)
+		return nil
+	}
+	return unstructured.SetNestedStringMap(obj.Object, annotations, podAnnotationsPath...)
+}
+
+// updateSpecPodAnnotations sets the binding hash in the pod template annotations when using the
+// hash annotation restart strategy, otherwise removing it when injected by a former strategy.
+func (b *Binder) updateSpecPodAnnotations(
+	obj *unstructured.Unstructured,
+	injected *injections,
+) (*unstructured.Unstructured, error) {
+	if b.restartStrategy() == v1alpha1.RestartStrategyHashAnnotation {
+		err := updateBindingHashes(obj, injected.adapter(obj).PodAnnotationsPath(), func(hashes map[string]string) {
+			hashes[b.namespacedName().String()] = b.dataHash
+		})
+		if err != nil {
+			return nil, err
+		}
+		injected.BindingHash = true
+		return obj, nil
+	}
+	return b.removeSpecPodAnnotations(obj, injected)
+}
+
+// removeSpecPodAnnotations removes the binding hash from the pod template annotations, when
+// injected.
+func (b *Binder) removeSpecPodAnnotations(
+	obj *unstructured.Unstructured,
+	injected *injections,
+) (*unstructured.Unstructured, error) {
+	if !injected.BindingHash {
+		return obj, nil
+	}
+	err := updateBindingHashes(obj, injected.adapter(obj).PodAnnotationsPath(), func(hashes map[string]string) {
+		delete(hashes, b.namespacedName().String())
+	})
+	if err != nil {
+		return nil, err
+	}
+	injected.BindingHash = false
+	return obj, nil
+}
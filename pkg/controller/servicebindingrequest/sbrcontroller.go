@@ -1,10 +1,14 @@
 package servicebindingrequest
 
 import (
+	"errors"
 	"os"
+	"reflect"
 	"strings"
+	"sync"
 
 	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -16,20 +20,27 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
 )
 
 // SBRController hold the controller instance and methods for a ServiceBindingRequest.
 type SBRController struct {
-	Controller   controller.Controller            // controller-runtime instance
-	Client       dynamic.Interface                // kubernetes dynamic api client
-	watchingGVKs map[schema.GroupVersionKind]bool // cache to identify GVKs on watch
-	logger       *log.Log                         // logger instance
+	Controller              controller.Controller            // controller-runtime instance
+	Client                  dynamic.Interface                // kubernetes dynamic api client
+	RESTMapper              meta.RESTMapper                  // maps kinds to resources and back
+	watchingGVKs            map[schema.GroupVersionKind]bool // cache to identify GVKs on watch
+	watchingApplicationGVKs map[schema.GroupVersionKind]bool // cache to identify applications on watch
+	applications            *applicationIndex                // reverse index of applications to SBRs
+	boundObjects            *boundObjectIndex                // reverse index of bound objects to SBRs
+	requests                bindingRequests                  // kind of resources expressing binding intent
+	lock                    sync.Mutex                       // guards the watch caches
+	logger                  *log.Log                         // logger instance
 }
 
-// controllerName common name of this controller
-const controllerName = "servicebindingrequest-controller"
+// controllerName returns the name of the controller reconciling the informed kind.
+func controllerName(gvk schema.GroupVersionKind) string {
+	return strings.ToLower(gvk.Kind) + "-controller"
+}
 
 // compareObjectFields compares a nested field of two given objects.
 func compareObjectFields(objOld, objNew runtime.Object, fields ...string) (bool, error) {
@@ -49,10 +60,13 @@ func compareObjectFields(objOld, objNew runtime.Object, fields ...string) (bool,
 	)
 }
 
-// newEnqueueRequestsForSBR returns a handler.EventHandler configured to map any incoming object to a
-// ServiceBindingRequest if it contains the required configuration.
+// newEnqueueRequestsForSBR returns a handler.EventHandler configured to map any incoming object to
+// the ServiceBindingRequests depending on it.
 func (s *SBRController) newEnqueueRequestsForSBR() handler.EventHandler {
-	return &handler.EnqueueRequestsFromMapFunc{ToRequests: &SBRRequestMapper{}}
+	return &handler.EnqueueRequestsFromMapFunc{ToRequests: &SBRRequestMapper{
+		gvk:          s.requests.GroupVersionKind(),
+		boundObjects: s.boundObjects,
+	}}
 }
 
 // createSourceForGVK creates a *source.Kind for the given gvk.
@@ -67,6 +81,9 @@ func (s *SBRController) createUnstructuredWithGVK(gvk schema.GroupVersionKind) *
 	return u
 }
 
+// routeGVK is the GVK of OpenShift routes.
+var routeGVK = schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}
+
 // getWatchingGVKs return a list of GVKs that this controller is interested in watching.
 func (s *SBRController) getWatchingGVKs() ([]schema.GroupVersionKind, error) {
 	log := s.logger
@@ -74,6 +91,16 @@ func (s *SBRController) getWatchingGVKs() ([]schema.GroupVersionKind, error) {
 	gvks := []schema.GroupVersionKind{
 		{Group: "", Version: "v1", Kind: "Secret"},
 		{Group: "", Version: "v1", Kind: "ConfigMap"},
+		{Group: "", Version: "v1", Kind: "Service"},
+	}
+	// routes are core backing services as well, and BindableServices describe backing service
+	// kinds, both only watched when served by the cluster
+	for _, gvk := range []schema.GroupVersionKind{routeGVK, bindableServiceGVK} {
+		if _, err := resourceForKind(s.RESTMapper, gvk); err == nil {
+			gvks = append(gvks, gvk)
+		} else if !errors.Is(err, UnknownKindErr) {
+			return nil, err
+		}
 	}
 
 	olm := NewOLM(s.Client, os.Getenv("WATCH_NAMESPACE"))
@@ -91,8 +118,38 @@ func isOfKind(obj runtime.Object, kind string) bool {
 	return strings.EqualFold(obj.GetObjectKind().GroupVersionKind().Kind, kind)
 }
 
-// updateEvent returns a predicate handler function.
-func updateFunc(logger *log.Log) func(updateEvent event.UpdateEvent) bool {
+// statusPathsChanged evaluates whether any of the informed status paths differ between the given
+// objects.
+func statusPathsChanged(objOld, objNew runtime.Object, paths []string) (bool, error) {
+	mapOld, err := runtime.DefaultUnstructuredConverter.ToUnstructured(objOld)
+	if err != nil {
+		return false, err
+	}
+	mapNew, err := runtime.DefaultUnstructuredConverter.ToUnstructured(objNew)
+	if err != nil {
+		return false, err
+	}
+
+	for _, path := range paths {
+		fields := statusPathFields(path)
+		valueOld, _, err := unstructured.NestedFieldNoCopy(mapOld, fields...)
+		if err != nil {
+			return false, err
+		}
+		valueNew, _, err := unstructured.NestedFieldNoCopy(mapNew, fields...)
+		if err != nil {
+			return false, err
+		}
+		if !reflect.DeepEqual(valueOld, valueNew) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// updateEvent returns a predicate handler function. Status updates are taken into account for
+// objects SBRs depend on, as long as the status paths they depend on have changed.
+func updateFunc(logger *log.Log, boundObjects *boundObjectIndex) func(updateEvent event.UpdateEvent) bool {
 	return func(e event.UpdateEvent) bool {
 		isSecret := isOfKind(e.ObjectNew, "Secret")
 		isConfigMap := isOfKind(e.ObjectNew, "ConfigMap")
@@ -108,16 +165,48 @@ func updateFunc(logger *log.Log) func(updateEvent event.UpdateEvent) bool {
 			return !dataFieldsAreEqual
 		}
 
-		// ignore updates to CR status in which case metadata.Generation does not change
-		return e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+		if e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration() {
+			return true
+		}
+
+		// services don't have metadata.Generation updated when their spec changes
+		if isOfKind(e.ObjectNew, "Service") {
+			specsAreEqual, err := compareObjectFields(e.ObjectNew, e.ObjectOld, "spec")
+			if err != nil {
+				logger.Error(err, "error comparing object fields: %s", err.Error())
+				return false
+			}
+			if !specsAreEqual {
+				logger.Debug("Predicate evaluated", "specsAreEqual", specsAreEqual)
+				return true
+			}
+		}
+
+		// updates to CR status don't change metadata.Generation, those are ignored unless SBRs
+		// depend on the changed status paths
+		if boundObjects == nil {
+			return false
+		}
+		ref := objectReferenceFor(e.ObjectNew.GetObjectKind().GroupVersionKind(), e.MetaNew)
+		paths, found := boundObjects.statusPaths(ref)
+		if !found {
+			return false
+		}
+		changed, err := statusPathsChanged(e.ObjectOld, e.ObjectNew, paths)
+		if err != nil {
+			logger.Error(err, "error comparing status paths")
+			return false
+		}
+		logger.Debug("Predicate evaluated", "statusPathsChanged", changed)
+		return changed
 	}
 }
 
 // buildGVKPredicate construct the predicates for all other GVKs, unless SBR.
-func buildGVKPredicate(logger *log.Log) predicate.Funcs {
+func buildGVKPredicate(logger *log.Log, boundObjects *boundObjectIndex) predicate.Funcs {
 	logger = logger.WithName("buildGVKPredicate")
 	return predicate.Funcs{
-		UpdateFunc: updateFunc(logger),
+		UpdateFunc: updateFunc(logger, boundObjects),
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			// evaluates to false if the object has been confirmed deleted
 			return !e.DeleteStateUnknown
@@ -125,10 +214,74 @@ func buildGVKPredicate(logger *log.Log) predicate.Funcs {
 	}
 }
 
+// buildApplicationPredicate construct the predicates for application GVKs, reconciling SBRs when
+// applications are created, deleted or have their labels changed, since those may change whether
+// they are selected, and when bound applications are missing binding items.
+func buildApplicationPredicate(logger *log.Log) predicate.Funcs {
+	logger = logger.WithName("buildApplicationPredicate")
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			labelsAreEqual := reflect.DeepEqual(e.MetaOld.GetLabels(), e.MetaNew.GetLabels())
+			if !labelsAreEqual {
+				logger.Debug("Predicate evaluated", "labelsAreEqual", labelsAreEqual)
+				return true
+			}
+			drifted, err := hasDrifted(e.ObjectOld, e.ObjectNew)
+			if err != nil {
+				logger.Error(err, "error inspecting object for binding drift")
+				return false
+			}
+			logger.Debug("Predicate evaluated", "labelsAreEqual", labelsAreEqual, "drifted", drifted)
+			return drifted
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			// evaluates to false if the object has been confirmed deleted
+			return !e.DeleteStateUnknown
+		},
+	}
+}
+
+// AddWatchForApplicationGVR creates a watch on the kind of the given application resource, as long
+// as it's not duplicated, returning the kind.
+func (s *SBRController) AddWatchForApplicationGVR(
+	gvr schema.GroupVersionResource,
+) (schema.GroupVersionKind, error) {
+	gvk, err := s.RESTMapper.KindFor(gvr)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+
+	logger := s.logger.WithValues("GVK", gvk)
+	logger.Debug("Adding watch for application GVK...")
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, exists := s.watchingApplicationGVKs[gvk]; exists {
+		logger.Debug("Skipping watch on application GVK twice, it's already under watch!")
+		return gvk, nil
+	}
+
+	logger.Debug("Creating watch on application GVK")
+	src := s.createSourceForGVK(gvk)
+	mapper := &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: &ApplicationToSBRMapper{applications: s.applications},
+	}
+	if err = s.Controller.Watch(src, mapper, buildApplicationPredicate(logger)); err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+
+	// saving GVK in cache only when the watch is in place, so failures are retried
+	s.watchingApplicationGVKs[gvk] = true
+	return gvk, nil
+}
+
 // AddWatchForGVK creates a watch on a given GVK, as long as it's not duplicated.
 func (s *SBRController) AddWatchForGVK(gvk schema.GroupVersionKind) error {
 	logger := s.logger.WithValues("GVK", gvk)
 	logger.Debug("Adding watch for GVK...")
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	if _, exists := s.watchingGVKs[gvk]; exists {
 		logger.Debug("Skipping watch on GVK twice, it's already under watch!")
 		return nil
@@ -139,7 +292,7 @@ func (s *SBRController) AddWatchForGVK(gvk schema.GroupVersionKind) error {
 
 	logger.Debug("Creating watch on GVK")
 	src := s.createSourceForGVK(gvk)
-	return s.Controller.Watch(src, s.newEnqueueRequestsForSBR(), buildGVKPredicate(logger))
+	return s.Controller.Watch(src, s.newEnqueueRequestsForSBR(), buildGVKPredicate(logger, s.boundObjects))
 }
 
 // addCSVWatch creates a watch on ClusterServiceVersion.
@@ -156,6 +309,29 @@ func (s *SBRController) addCSVWatch() error {
 	return nil
 }
 
+// addCRDWatch creates a watch on CustomResourceDefinition, in the version preferred by the cluster,
+// to reset the RESTMapper when CRDs change. It's skipped when the RESTMapper can't be reset.
+func (s *SBRController) addCRDWatch() error {
+	log := s.logger
+	restMapper, ok := s.RESTMapper.(resettableRESTMapper)
+	if !ok {
+		log.Debug("RESTMapper can't be reset, skipping watch for CustomResourceDefinition")
+		return nil
+	}
+	mapping, err := restMapper.RESTMapping(crdGroupKind)
+	if err != nil {
+		return err
+	}
+	source := s.createSourceForGVK(mapping.GroupVersionKind)
+	err = s.Controller.Watch(source, NewCRDWatchEventHandler(restMapper))
+	if err != nil {
+		return err
+	}
+	log.Debug("Watch added for CustomResourceDefinition", "GVK", mapping.GroupVersionKind)
+
+	return nil
+}
+
 // buildSBRPredicate construct the predicates for service-binding-requests.
 func buildSBRPredicate(logger *log.Log) predicate.Funcs {
 	logger = logger.WithName("buildSBRPredicate")
@@ -191,9 +367,9 @@ func buildSBRPredicate(logger *log.Log) predicate.Funcs {
 	}
 }
 
-// addSBRWatch creates a watchon ServiceBindingRequest GVK.
+// addSBRWatch creates a watchon ServiceBindingRequest GVK, or the kind translated into it.
 func (s *SBRController) addSBRWatch() error {
-	gvk := v1alpha1.SchemeGroupVersion.WithKind(ServiceBindingRequestKind)
+	gvk := s.requests.GroupVersionKind()
 	l := s.logger.WithValues("GKV", gvk)
 	src := s.createSourceForGVK(gvk)
 	err := s.Controller.Watch(src, s.newEnqueueRequestsForSBR(), buildSBRPredicate(l))
@@ -206,6 +382,22 @@ func (s *SBRController) addSBRWatch() error {
 	return nil
 }
 
+// addApplicationWatch creates a watch on ServiceBindingRequest GVK to index their application
+// selectors and watch the application kinds they refer to.
+func (s *SBRController) addApplicationWatch() error {
+	gvk := s.requests.GroupVersionKind()
+	l := s.logger.WithValues("GKV", gvk)
+	src := s.createSourceForGVK(gvk)
+	err := s.Controller.Watch(src, NewApplicationWatchEventHandler(s))
+	if err != nil {
+		l.Error(err, "on creating watch for ServiceBindingRequest applications")
+		return err
+	}
+	l.Debug("Watch added for ServiceBindingRequest applications")
+
+	return nil
+}
+
 // addWhitelistedGVKWatches create watch on GVKs employed on CSVs.
 func (s *SBRController) addWhitelistedGVKWatches() error {
 	log := s.logger
@@ -237,6 +429,12 @@ func (s *SBRController) Watch() error {
 		return err
 	}
 
+	err = s.addApplicationWatch()
+	if err != nil {
+		log.Error(err, "on adding watch for ServiceBindingRequest applications")
+		return err
+	}
+
 	err = s.addWhitelistedGVKWatches()
 	if err != nil {
 		log.Error(err, "on adding watch for whitelisted GVKs")
@@ -249,25 +447,36 @@ func (s *SBRController) Watch() error {
 		return err
 	}
 
+	err = s.addCRDWatch()
+	if err != nil {
+		log.Error(err, "on adding watch for CustomResourceDefinition")
+		return err
+	}
+
 	return nil
 }
 
-// NewSBRController creates a new SBRController instance. It can return error on bootstrapping a new
-// dynamic client.
+// NewSBRController creates a new SBRController instance, reconciling the kind of the informed
+// bindingRequests. It can return error on bootstrapping a new dynamic client.
 func NewSBRController(
 	mgr manager.Manager,
 	options controller.Options,
 	client dynamic.Interface,
+	requests bindingRequests,
 ) (*SBRController, error) {
-	c, err := controller.New(controllerName, mgr, options)
+	c, err := controller.New(controllerName(requests.GroupVersionKind()), mgr, options)
 	if err != nil {
 		return nil, err
 	}
 
 	return &SBRController{
-		Controller:   c,
-		Client:       client,
-		watchingGVKs: make(map[schema.GroupVersionKind]bool),
-		logger:       log.NewLog("sbrcontroller"),
+		Controller:              c,
+		Client:                  client,
+		RESTMapper:              mgr.GetRESTMapper(),
+		watchingGVKs:            make(map[schema.GroupVersionKind]bool),
+		watchingApplicationGVKs: make(map[schema.GroupVersionKind]bool),
+		applications:            newApplicationIndex(),
+		requests:                requests,
+		logger:                  log.NewLog("sbrcontroller"),
 	}, nil
 }
@@ -3,14 +3,20 @@ package servicebindingrequest
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
 )
 
 func TestSBRControllerBuildSBRPredicate(t *testing.T) {
@@ -83,7 +89,7 @@ func TestSBRControllerBuildSBRPredicate(t *testing.T) {
 }
 
 func TestSBRControllerBuildGVKPredicate(t *testing.T) {
-	pred := buildGVKPredicate(log.NewLog("test-log"))
+	pred := buildGVKPredicate(log.NewLog("test-log"), newBoundObjectIndex())
 
 	// update verifies whether only the accepted manifests trigger the reconciliation process
 	t.Run("update", func(t *testing.T) {
@@ -108,6 +114,12 @@ func TestSBRControllerBuildGVKPredicate(t *testing.T) {
 				Generation: 2,
 			},
 		}
+		serviceA := &corev1.Service{
+			TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"},
+			Spec:     corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 5432}}},
+		}
+		serviceB := serviceA.DeepCopy()
+		serviceB.Spec.Ports[0].Port = 5433
 
 		tests := []struct {
 			name   string
@@ -157,6 +169,22 @@ func TestSBRControllerBuildGVKPredicate(t *testing.T) {
 				metaA:  secretA.GetObjectMeta(),
 				metaB:  secretB.GetObjectMeta(),
 			},
+			{
+				name:   "service spec changed",
+				wanted: true,
+				a:      serviceA,
+				b:      serviceB,
+				metaA:  serviceA.GetObjectMeta(),
+				metaB:  serviceB.GetObjectMeta(),
+			},
+			{
+				name:   "service no changes",
+				wanted: false,
+				a:      serviceA,
+				b:      serviceA,
+				metaA:  serviceA.GetObjectMeta(),
+				metaB:  serviceA.GetObjectMeta(),
+			},
 		}
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
@@ -173,3 +201,151 @@ func TestSBRControllerBuildGVKPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestSBRControllerBuildGVKPredicateStatus(t *testing.T) {
+	boundObjects := newBoundObjectIndex()
+	pred := buildGVKPredicate(log.NewLog("test-log"), boundObjects)
+
+	// database returns a backing service CR with the informed status.
+	database := func(name string, status map[string]interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{"status": status}}
+		u.SetGroupVersionKind(schema.GroupVersionKind{Group: "postgresql.baiju.dev", Version: "v1alpha1", Kind: "Database"})
+		u.SetNamespace("predicate")
+		u.SetName(name)
+		u.SetGeneration(1)
+		return u
+	}
+
+	sbr := types.NamespacedName{Namespace: "predicate", Name: "sbr"}
+	bound := database("bound", map[string]interface{}{"dbCredentials": "a", "phase": "Creating"})
+	boundObjects.set(sbr, map[objectReference][]string{
+		objectReferenceFor(bound.GroupVersionKind(), bound): {"dbCredentials"},
+	})
+	unbound := database("unbound", map[string]interface{}{"dbCredentials": "a"})
+
+	tests := []struct {
+		name   string
+		wanted bool
+		a      *unstructured.Unstructured
+		b      *unstructured.Unstructured
+	}{
+		{
+			name:   "bound status path changed",
+			wanted: true,
+			a:      bound,
+			b:      database("bound", map[string]interface{}{"dbCredentials": "b", "phase": "Creating"}),
+		},
+		{
+			name:   "bound other status changed",
+			wanted: false,
+			a:      bound,
+			b:      database("bound", map[string]interface{}{"dbCredentials": "a", "phase": "Ready"}),
+		},
+		{
+			name:   "unbound status changed",
+			wanted: false,
+			a:      unbound,
+			b:      database("unbound", map[string]interface{}{"dbCredentials": "b"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := event.UpdateEvent{MetaOld: tt.a, MetaNew: tt.b, ObjectOld: tt.a, ObjectNew: tt.b}
+			if got := pred.Update(e); got != tt.wanted {
+				t.Errorf("buildGVKPredicate() = %v, want %v", got, tt.wanted)
+			}
+		})
+	}
+}
+
+func TestSBRControllerBuildApplicationPredicate(t *testing.T) {
+	pred := buildApplicationPredicate(log.NewLog("test-log"))
+
+	// new applications may be selected, so they must be reconciled
+	t.Run("create", func(t *testing.T) {
+		if got := pred.Create(event.CreateEvent{}); !got {
+			t.Errorf("buildApplicationPredicate() = %v, want %v", got, true)
+		}
+	})
+
+	// only label changes may change whether applications are selected, and bound applications
+	// missing binding items must have those re-applied
+	t.Run("update", func(t *testing.T) {
+		deploymentA := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Generation: 1,
+				Labels:     map[string]string{"connects-to": "database"},
+			},
+		}
+		deploymentB := deploymentA.DeepCopy()
+		deploymentB.Generation = 2
+		deploymentC := deploymentA.DeepCopy()
+		deploymentC.Labels = nil
+		// bound deployment, and the same deployment having binding items removed
+		deploymentD := deploymentA.DeepCopy()
+		deploymentD.Annotations = map[string]string{
+			injectionsAnnotation: `{"ns/sbr":{"containers":{"app":{"envFrom":["sbr"]}}}}`,
+		}
+		deploymentD.Spec.Template.Spec.Containers = []corev1.Container{{
+			Name: "app",
+			EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "sbr"}},
+			}},
+		}}
+		deploymentE := deploymentD.DeepCopy()
+		deploymentE.Spec.Template.Spec.Containers[0].EnvFrom = nil
+
+		tests := []struct {
+			name   string
+			wanted bool
+			a      *appsv1.Deployment
+			b      *appsv1.Deployment
+		}{
+			{name: "no changes", wanted: false, a: deploymentA, b: deploymentA},
+			{name: "generation changed", wanted: false, a: deploymentA, b: deploymentB},
+			{name: "labels changed", wanted: true, a: deploymentA, b: deploymentC},
+			{name: "binding items kept", wanted: false, a: deploymentD, b: deploymentD},
+			{name: "binding items removed", wanted: true, a: deploymentD, b: deploymentE},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				e := event.UpdateEvent{
+					MetaOld:   tt.a.GetObjectMeta(),
+					MetaNew:   tt.b.GetObjectMeta(),
+					ObjectOld: tt.a,
+					ObjectNew: tt.b,
+				}
+				if got := pred.Update(e); got != tt.wanted {
+					t.Errorf("buildApplicationPredicate() = %v, want %v", got, tt.wanted)
+				}
+			})
+		}
+	})
+}
+
+// TestSBRControllerGetWatchingGVKs checks routes are watched along with the other core backing
+// services, and so are BindableServices, as long as the cluster serves them.
+func TestSBRControllerGetWatchingGVKs(t *testing.T) {
+	f := mocks.NewFake(t, "controller")
+	f.AddMockedUnstructuredCSV("csv")
+
+	for _, served := range []bool{true, false} {
+		mapper := meta.NewDefaultRESTMapper(nil)
+		if served {
+			mapper.Add(routeGVK, meta.RESTScopeNamespace)
+			mapper.Add(bindableServiceGVK, meta.RESTScopeRoot)
+		}
+		s := &SBRController{Client: f.FakeDynClient(), RESTMapper: mapper, logger: log.NewLog("test-log")}
+
+		gvks, err := s.getWatchingGVKs()
+		require.NoError(t, err)
+		require.Contains(t, gvks, schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Service"})
+		if served {
+			require.Contains(t, gvks, routeGVK)
+			require.Contains(t, gvks, bindableServiceGVK)
+		} else {
+			require.NotContains(t, gvks, routeGVK)
+			require.NotContains(t, gvks, bindableServiceGVK)
+		}
+	}
+}
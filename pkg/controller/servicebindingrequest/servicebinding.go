@@ -0,0 +1,221 @@
+package servicebindingrequest
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3"
+	"github.com/redhat-developer/service-binding-operator/pkg/converter"
+)
+
+// serviceBindingGVR is the resource of servicebinding.io ServiceBindings.
+var serviceBindingGVR = v1alpha3.SchemeGroupVersion.WithResource(ServiceBindingResource)
+
+// sbrFromServiceBinding translates the informed ServiceBinding into the equivalent
+// ServiceBindingRequest, binding as files, along with the environment variables referring to the
+// binding secret keys declared in "env", found as they are in the binding secret since keys are
+// stored unprefixed when binding as files. The translation keeps the ServiceBinding's type meta,
+// so events refer to it. The workload resource is resolved unless the ServiceBinding is being
+// deleted, since unbinding depends only on the workloads recorded in its status.
+func sbrFromServiceBinding(
+	restMapper meta.RESTMapper,
+	sb *v1alpha3.ServiceBinding,
+) (*v1alpha1.ServiceBindingRequest, []corev1.EnvVar, error) {
+	serviceGVK := schema.FromAPIVersionAndKind(sb.Spec.Service.APIVersion, sb.Spec.Service.Kind)
+	workloadGVK := schema.FromAPIVersionAndKind(sb.Spec.Workload.APIVersion, sb.Spec.Workload.Kind)
+	workloadGVR := workloadGVK.GroupVersion().WithResource("")
+	if sb.GetDeletionTimestamp() == nil {
+		var err error
+		if workloadGVR, err = resourceForKind(restMapper, workloadGVK); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	customEnvVar := []corev1.EnvVar{}
+	for _, m := range sb.Spec.Mappings {
+		customEnvVar = append(customEnvVar, corev1.EnvVar{Name: m.Name, Value: m.Value})
+	}
+
+	sbr := &v1alpha1.ServiceBindingRequest{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha3.SchemeGroupVersion.String(), Kind: ServiceBindingKind},
+		ObjectMeta: *sb.ObjectMeta.DeepCopy(),
+		Spec: v1alpha1.ServiceBindingRequestSpec{
+			CustomEnvVar: customEnvVar,
+			BackingServiceSelectors: &[]v1alpha1.BackingServiceSelector{{
+				GroupVersionKind: metav1.GroupVersionKind{
+					Group:   serviceGVK.Group,
+					Version: serviceGVK.Version,
+					Kind:    serviceGVK.Kind,
+				},
+				ResourceRef: sb.Spec.Service.Name,
+			}},
+			ApplicationSelector: v1alpha1.ApplicationSelector{
+				LabelSelector: sb.Spec.Workload.Selector.DeepCopy(),
+				GroupVersionResource: metav1.GroupVersionResource{
+					Group:    workloadGVR.Group,
+					Version:  workloadGVR.Version,
+					Resource: workloadGVR.Resource,
+				},
+				ResourceRef: sb.Spec.Workload.Name,
+			},
+			BindAsFiles: true,
+			Type:        sb.Spec.Type,
+			Provider:    sb.Spec.Provider,
+		},
+		Status: v1alpha1.ServiceBindingRequestStatus{
+			Conditions: sb.Status.Conditions,
+			DriftCount: sb.Status.DriftCount,
+		},
+	}
+	if sb.Status.Binding != nil {
+		sbr.Status.Secret = sb.Status.Binding.Name
+	}
+	for _, w := range sb.Status.Workloads {
+		gvk := schema.FromAPIVersionAndKind(w.APIVersion, w.Kind)
+		sbr.Status.ApplicationObjects = append(sbr.Status.ApplicationObjects, v1alpha1.BoundApplication{
+			GroupVersionKind:     metav1.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind},
+			LocalObjectReference: corev1.LocalObjectReference{Name: w.Name},
+		})
+	}
+
+	var env []corev1.EnvVar
+	for _, e := range sb.Spec.Env {
+		env = append(env, corev1.EnvVar{
+			Name: e.Name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: sb.GetName()},
+					Key:                  e.Key,
+				},
+			},
+		})
+	}
+	return sbr, env, nil
+}
+
+// serviceBindingStatusFromSBR translates the status of the informed ServiceBindingRequest, obtained
+// from a ServiceBinding, back into the ServiceBinding status.
+func serviceBindingStatusFromSBR(sbr *v1alpha1.ServiceBindingRequest) v1alpha3.ServiceBindingStatus {
+	status := v1alpha3.ServiceBindingStatus{
+		ObservedGeneration: sbr.GetGeneration(),
+		Conditions:         sbr.Status.Conditions,
+		DriftCount:         sbr.Status.DriftCount,
+	}
+	if sbr.Status.Secret != "" {
+		status.Binding = &corev1.LocalObjectReference{Name: sbr.Status.Secret}
+	}
+	for _, app := range sbr.Status.ApplicationObjects {
+		status.Workloads = append(status.Workloads, v1alpha3.BoundWorkload{
+			APIVersion: schema.GroupVersion{Group: app.Group, Version: app.Version}.String(),
+			Kind:       app.Kind,
+			Name:       app.Name,
+		})
+	}
+	return status
+}
+
+// serviceBindingRequests reads and writes servicebinding.io ServiceBindings, translating those to
+// and from ServiceBindingRequests.
+type serviceBindingRequests struct {
+	dynClient  dynamic.Interface // kubernetes dynamic api client
+	restMapper meta.RESTMapper   // maps workload kinds to resources
+}
+
+// GroupVersionKind returns the ServiceBinding kind.
+func (r *serviceBindingRequests) GroupVersionKind() schema.GroupVersionKind {
+	return v1alpha3.SchemeGroupVersion.WithKind(ServiceBindingKind)
+}
+
+// resourceClient returns the ServiceBinding client for the informed namespace.
+func (r *serviceBindingRequests) resourceClient(ns string) dynamic.ResourceInterface {
+	return r.dynClient.Resource(serviceBindingGVR).Namespace(ns)
+}
+
+// FromUnstructured translates the informed ServiceBinding.
+func (r *serviceBindingRequests) FromUnstructured(
+	u *unstructured.Unstructured,
+) (*v1alpha1.ServiceBindingRequest, []corev1.EnvVar, error) {
+	sb := &v1alpha3.ServiceBinding{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, sb); err != nil {
+		return nil, nil, err
+	}
+	return sbrFromServiceBinding(r.restMapper, sb)
+}
+
+// Get reads and translates the ServiceBinding having the informed namespaced name.
+func (r *serviceBindingRequests) Get(
+	namespacedName types.NamespacedName,
+) (*v1alpha1.ServiceBindingRequest, []corev1.EnvVar, error) {
+	u, err := r.resourceClient(namespacedName.Namespace).Get(namespacedName.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.FromUnstructured(u)
+}
+
+// write reads the ServiceBinding the informed ServiceBindingRequest was translated from, having it
+// changed by mutate as of the translated resource version, and written by the informed function.
+// The written ServiceBinding is translated back.
+func (r *serviceBindingRequests) write(
+	sbr *v1alpha1.ServiceBindingRequest,
+	mutate func(sb *v1alpha3.ServiceBinding),
+	write func(ri dynamic.ResourceInterface, u *unstructured.Unstructured) (*unstructured.Unstructured, error),
+) (*v1alpha1.ServiceBindingRequest, error) {
+	ri := r.resourceClient(sbr.GetNamespace())
+	u, err := ri.Get(sbr.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sb := &v1alpha3.ServiceBinding{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, sb); err != nil {
+		return nil, err
+	}
+	sb.SetResourceVersion(sbr.GetResourceVersion())
+	mutate(sb)
+
+	if u, err = converter.ToUnstructuredAsGVK(sb, r.GroupVersionKind()); err != nil {
+		return nil, err
+	}
+	if u, err = write(ri, u); err != nil {
+		return nil, err
+	}
+	translated, _, err := r.FromUnstructured(u)
+	return translated, err
+}
+
+// Update writes the finalizers of the informed translated ServiceBindingRequest.
+func (r *serviceBindingRequests) Update(
+	sbr *v1alpha1.ServiceBindingRequest,
+) (*v1alpha1.ServiceBindingRequest, error) {
+	return r.write(
+		sbr,
+		func(sb *v1alpha3.ServiceBinding) {
+			sb.SetFinalizers(sbr.GetFinalizers())
+		},
+		func(ri dynamic.ResourceInterface, u *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+			return ri.Update(u, metav1.UpdateOptions{})
+		},
+	)
+}
+
+// UpdateStatus writes the status of the informed translated ServiceBindingRequest.
+func (r *serviceBindingRequests) UpdateStatus(
+	sbr *v1alpha1.ServiceBindingRequest,
+) (*v1alpha1.ServiceBindingRequest, error) {
+	return r.write(
+		sbr,
+		func(sb *v1alpha3.ServiceBinding) {
+			sb.Status = serviceBindingStatusFromSBR(sbr)
+		},
+		func(ri dynamic.ResourceInterface, u *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+			return ri.UpdateStatus(u, metav1.UpdateOptions{})
+		},
+	)
+}
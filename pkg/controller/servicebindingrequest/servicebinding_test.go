@@ -0,0 +1,276 @@
+package servicebindingrequest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	conditionsv1 "github.com/openshift/custom-resource-status/conditions/v1"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3"
+	"github.com/redhat-developer/service-binding-operator/pkg/conditions"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+var deploymentGVK = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+func TestSBRFromServiceBinding(t *testing.T) {
+	ns := "servicebinding"
+	matchLabels := map[string]string{"connects-to": "database"}
+	f := mocks.NewFake(t, ns)
+	restMapper := f.FakeRESTMapper()
+
+	sb := mocks.ServiceBindingMock(ns, "sb", "db", "", deploymentGVK, matchLabels)
+	sb.Spec.Type = "postgresql"
+	sb.Spec.Env = []v1alpha3.EnvMapping{{Name: "DB_PASSWORD", Key: "password"}}
+
+	t.Run("spec", func(t *testing.T) {
+		sbr, env, err := sbrFromServiceBinding(restMapper, sb)
+		require.NoError(t, err)
+		require.Equal(t, v1alpha3.SchemeGroupVersion.WithKind(ServiceBindingKind), sbr.GroupVersionKind())
+		require.Equal(t, "sb", sbr.GetName())
+		require.True(t, sbr.Spec.BindAsFiles)
+		require.Equal(t, "postgresql", sbr.Spec.Type)
+		require.Equal(t, []corev1.EnvVar{{Name: "IMAGE_PATH", Value: "spec.imagePath"}}, sbr.Spec.CustomEnvVar)
+		require.Equal(t, &[]v1alpha1.BackingServiceSelector{{
+			GroupVersionKind: metav1.GroupVersionKind{Group: mocks.CRDName, Version: mocks.CRDVersion, Kind: mocks.CRDKind},
+			ResourceRef:      "db",
+		}}, sbr.Spec.BackingServiceSelectors)
+		require.Equal(t, v1alpha1.ApplicationSelector{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: matchLabels},
+			GroupVersionResource: metav1.GroupVersionResource{
+				Group:    deploymentsGVR.Group,
+				Version:  deploymentsGVR.Version,
+				Resource: deploymentsGVR.Resource,
+			},
+		}, sbr.Spec.ApplicationSelector)
+
+		require.Len(t, env, 1)
+		require.Equal(t, "DB_PASSWORD", env[0].Name)
+		require.Equal(t, "sb", env[0].ValueFrom.SecretKeyRef.Name)
+		require.Equal(t, "password", env[0].ValueFrom.SecretKeyRef.Key)
+	})
+
+	t.Run("unknown workload kind", func(t *testing.T) {
+		_, _, err := sbrFromServiceBinding(meta.NewDefaultRESTMapper(nil), sb)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, UnknownKindErr))
+
+		// unbinding doesn't depend on the workload kind
+		deleted := sb.DeepCopy()
+		now := metav1.Now()
+		deleted.SetDeletionTimestamp(&now)
+		_, _, err = sbrFromServiceBinding(meta.NewDefaultRESTMapper(nil), deleted)
+		require.NoError(t, err)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		sbr, _, err := sbrFromServiceBinding(restMapper, sb)
+		require.NoError(t, err)
+		sbr.Status = v1alpha1.ServiceBindingRequestStatus{
+			Conditions: []conditionsv1.Condition{{Type: conditions.BindingReady, Status: corev1.ConditionTrue}},
+			Secret:     "sb",
+			ApplicationObjects: []v1alpha1.BoundApplication{{
+				GroupVersionKind:     metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+				LocalObjectReference: corev1.LocalObjectReference{Name: "app"},
+			}},
+			DriftCount: 2,
+		}
+
+		bound := sb.DeepCopy()
+		bound.Status = serviceBindingStatusFromSBR(sbr)
+		require.Equal(t, &corev1.LocalObjectReference{Name: "sb"}, bound.Status.Binding)
+		require.Equal(t, []v1alpha3.BoundWorkload{{APIVersion: "apps/v1", Kind: "Deployment", Name: "app"}},
+			bound.Status.Workloads)
+
+		translated, _, err := sbrFromServiceBinding(restMapper, bound)
+		require.NoError(t, err)
+		require.Equal(t, sbr.Status, translated.Status)
+	})
+}
+
+// bindingResult is what binding produces, expected to be identical for equivalent
+// ServiceBindingRequests and ServiceBindings.
+type bindingResult struct {
+	secret     map[string]interface{}
+	template   corev1.PodTemplateSpec
+	secretName string
+	conditions []conditionsv1.Condition
+	bound      []v1alpha1.BoundApplication
+}
+
+// reconcileBinding reconciles the binding request added by the informed function, returning what
+// the binding has produced.
+func reconcileBinding(
+	t *testing.T,
+	addRequest func(f *mocks.Fake),
+	newRequests func(dynClient dynamic.Interface, restMapper meta.RESTMapper) bindingRequests,
+) *bindingResult {
+	backingServiceResourceRef := "conformance-db"
+	f := mocks.NewFake(t, reconcilerNs)
+	addRequest(f)
+	f.AddMockedUnstructuredCSV("cluster-service-version-list")
+	f.AddMockedUnstructuredDatabaseCRD()
+	f.AddMockedUnstructuredDatabaseCR(backingServiceResourceRef)
+	f.AddMockedUnstructuredDeployment(reconcilerName, map[string]string{"connects-to": "database"})
+	f.AddMockedSecret("db-credentials")
+
+	fakeClient := f.FakeClient()
+	fakeDynClient := f.FakeDynClient()
+	restMapper := f.FakeRESTMapper()
+	reconciler := &Reconciler{
+		client:     fakeClient,
+		dynClient:  fakeDynClient,
+		restMapper: restMapper,
+		scheme:     f.S,
+		requests:   newRequests(fakeDynClient, restMapper),
+	}
+
+	res, err := reconciler.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	sbr, err := reconciler.getServiceBindingRequest(namespacedName)
+	require.NoError(t, err)
+
+	secret, err := fakeDynClient.Resource(corev1.SchemeGroupVersion.WithResource(SecretResource)).
+		Namespace(reconcilerNs).
+		Get(sbr.Status.Secret, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	d := appsv1.Deployment{}
+	require.NoError(t, fakeClient.Get(context.TODO(), namespacedName, &d))
+
+	// heartbeat and transition times differ between reconciliations
+	for i := range sbr.Status.Conditions {
+		sbr.Status.Conditions[i].LastHeartbeatTime = metav1.Time{}
+		sbr.Status.Conditions[i].LastTransitionTime = metav1.Time{}
+	}
+	return &bindingResult{
+		secret:     secret.Object["data"].(map[string]interface{}),
+		template:   d.Spec.Template,
+		secretName: sbr.Status.Secret,
+		conditions: sbr.Status.Conditions,
+		bound:      sbr.Status.ApplicationObjects,
+	}
+}
+
+// TestServiceBindingConformance checks equivalent ServiceBindingRequests and ServiceBindings
+// produce identical bindings.
+func TestServiceBindingConformance(t *testing.T) {
+	backingServiceResourceRef := "conformance-db"
+	matchLabels := map[string]string{"connects-to": "database"}
+
+	tests := []struct {
+		name           string
+		applicationRef string
+		matchLabels    map[string]string
+	}{
+		{name: "workload selected by labels", matchLabels: matchLabels},
+		{name: "workload selected by name", applicationRef: reconcilerName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sbrResult := reconcileBinding(
+				t,
+				func(f *mocks.Fake) {
+					sbr := f.AddMockedServiceBindingRequest(
+						reconcilerName, nil, backingServiceResourceRef, tt.applicationRef, deploymentsGVR, tt.matchLabels)
+					sbr.Spec.BindAsFiles = true
+					sbr.Spec.MountPathPrefix = ""
+				},
+				func(dynClient dynamic.Interface, _ meta.RESTMapper) bindingRequests {
+					return &sbrRequests{dynClient: dynClient}
+				},
+			)
+			sbResult := reconcileBinding(
+				t,
+				func(f *mocks.Fake) {
+					f.AddMockedUnstructuredServiceBinding(
+						reconcilerName, backingServiceResourceRef, tt.applicationRef, deploymentGVK, tt.matchLabels)
+				},
+				func(dynClient dynamic.Interface, restMapper meta.RESTMapper) bindingRequests {
+					return &serviceBindingRequests{dynClient: dynClient, restMapper: restMapper}
+				},
+			)
+
+			require.Equal(t, reconcilerName, sbResult.secretName)
+			require.Len(t, sbResult.bound, 1)
+			require.Equal(t, corev1.ConditionTrue, sbResult.conditions[0].Status)
+			require.Contains(t, sbResult.secret, bindingTypeKey)
+			require.Equal(t, sbrResult, sbResult)
+		})
+	}
+}
+
+func TestServiceBindingEnv(t *testing.T) {
+	f := mocks.NewFake(t, reconcilerNs)
+	sb := f.AddMockedUnstructuredServiceBinding(
+		reconcilerName, "env-db", reconcilerName, deploymentGVK, nil)
+	env := []interface{}{map[string]interface{}{"name": "DB_PASSWORD", "key": "password"}}
+	require.NoError(t, unstructured.SetNestedSlice(sb.Object, env, "spec", "env"))
+	f.AddMockedUnstructuredCSV("cluster-service-version-list")
+	f.AddMockedUnstructuredDatabaseCRD()
+	f.AddMockedUnstructuredDatabaseCR("env-db")
+	f.AddMockedUnstructuredDeployment(reconcilerName, nil)
+	f.AddMockedSecret("db-credentials")
+
+	fakeClient := f.FakeClient()
+	fakeDynClient := f.FakeDynClient()
+	restMapper := f.FakeRESTMapper()
+	reconciler := &Reconciler{
+		client:     fakeClient,
+		dynClient:  fakeDynClient,
+		restMapper: restMapper,
+		scheme:     f.S,
+		requests:   &serviceBindingRequests{dynClient: fakeDynClient, restMapper: restMapper},
+	}
+
+	res, err := reconciler.Reconcile(reconcileRequest())
+	require.NoError(t, err)
+	require.False(t, res.Requeue)
+
+	namespacedName := types.NamespacedName{Namespace: reconcilerNs, Name: reconcilerName}
+	d := appsv1.Deployment{}
+	require.NoError(t, fakeClient.Get(context.TODO(), namespacedName, &d))
+	c := d.Spec.Template.Spec.Containers[0]
+	require.Equal(t, corev1.EnvVar{
+		Name: "DB_PASSWORD",
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: reconcilerName},
+				Key:                  "password",
+			},
+		},
+	}, *getEnvVar(c.Env, "DB_PASSWORD"))
+	require.NotNil(t, getEnvVar(c.Env, ServiceBindingRootEnv))
+
+	// the key referred to by the environment variable is found in the binding secret
+	secret, err := fakeDynClient.Resource(corev1.SchemeGroupVersion.WithResource(SecretResource)).
+		Namespace(reconcilerNs).
+		Get(reconcilerName, metav1.GetOptions{})
+	require.NoError(t, err)
+	data, _, err := unstructured.NestedStringMap(secret.Object, "data")
+	require.NoError(t, err)
+	require.Contains(t, data, "password")
+
+	// the status is written back to the ServiceBinding
+	u, err := fakeDynClient.Resource(serviceBindingGVR).Namespace(reconcilerNs).
+		Get(reconcilerName, metav1.GetOptions{})
+	require.NoError(t, err)
+	binding, _, err := unstructured.NestedString(u.Object, "status", "binding", "name")
+	require.NoError(t, err)
+	require.Equal(t, reconcilerName, binding)
+	require.Contains(t, u.GetFinalizers(), Finalizer)
+}
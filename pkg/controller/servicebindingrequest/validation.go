@@ -0,0 +1,256 @@
+package servicebindingrequest
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+)
+
+// validateDNS1123Subdomain appends an error per message returned by the DNS-1123 subdomain check.
+func validateDNS1123Subdomain(value string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsDNS1123Subdomain(value) {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, msg))
+	}
+	return allErrs
+}
+
+// validateDNS1035Label appends an error per message returned by the DNS-1035 label check.
+func validateDNS1035Label(value string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, msg := range validation.IsDNS1035Label(value) {
+		allErrs = append(allErrs, field.Invalid(fldPath, value, msg))
+	}
+	return allErrs
+}
+
+// validateGroupVersion checks the syntax of an API group and version pair, where an empty group
+// represents the core API group.
+func validateGroupVersion(group, version string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if group != "" {
+		allErrs = append(allErrs, validateDNS1123Subdomain(group, fldPath.Child("group"))...)
+	}
+	if version == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("version"), ""))
+	} else {
+		allErrs = append(allErrs, validateDNS1035Label(version, fldPath.Child("version"))...)
+	}
+	return allErrs
+}
+
+// validateGroupVersionKind checks the syntax of the informed GVK, kinds are validated the same way
+// the API server validates CRD kinds, by using its lower case form.
+func validateGroupVersionKind(gvk metav1.GroupVersionKind, fldPath *field.Path) field.ErrorList {
+	allErrs := validateGroupVersion(gvk.Group, gvk.Version, fldPath)
+	if gvk.Kind == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("kind"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1035Label(strings.ToLower(gvk.Kind)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("kind"), gvk.Kind, msg))
+		}
+	}
+	return allErrs
+}
+
+// validateGroupVersionResource checks the syntax of the informed GVR.
+func validateGroupVersionResource(gvr metav1.GroupVersionResource, fldPath *field.Path) field.ErrorList {
+	allErrs := validateGroupVersion(gvr.Group, gvr.Version, fldPath)
+	if gvr.Resource == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("resource"), ""))
+	} else {
+		allErrs = append(allErrs, validateDNS1035Label(gvr.Resource, fldPath.Child("resource"))...)
+	}
+	return allErrs
+}
+
+// validateBackingServiceSelector checks a single backing service selector.
+func validateBackingServiceSelector(
+	selector v1alpha1.BackingServiceSelector,
+	fldPath *field.Path,
+) field.ErrorList {
+	allErrs := validateGroupVersionKind(selector.GroupVersionKind, fldPath)
+	if selector.ResourceRef == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("resourceRef"), ""))
+	} else {
+		allErrs = append(allErrs, validateDNS1123Subdomain(selector.ResourceRef, fldPath.Child("resourceRef"))...)
+	}
+	if selector.Namespace != nil {
+		for _, msg := range validation.IsDNS1123Label(*selector.Namespace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), *selector.Namespace, msg))
+		}
+	}
+	// ids and prefixes become part of the binding keys, which are environment variable names
+	if selector.ID != "" {
+		for _, msg := range validation.IsCIdentifier(selector.ID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), selector.ID, msg))
+		}
+	}
+	if selector.EnvVarPrefix != nil && *selector.EnvVarPrefix != "" {
+		for _, msg := range validation.IsCIdentifier(*selector.EnvVarPrefix) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("envVarPrefix"), *selector.EnvVarPrefix, msg))
+		}
+	}
+	return allErrs
+}
+
+// validateBackingServiceSelectors checks both the deprecated single backing service selector and
+// the list of selectors, requiring at least one of them to be informed, and their ids to be unique.
+func validateBackingServiceSelectors(
+	spec *v1alpha1.ServiceBindingRequestSpec,
+	fldPath *field.Path,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.BackingServiceSelector == nil &&
+		(spec.BackingServiceSelectors == nil || len(*spec.BackingServiceSelectors) == 0) {
+		return append(allErrs, field.Required(
+			fldPath.Child("backingServiceSelectors"), EmptyBackingServiceSelectorsErr.Error()))
+	}
+	if spec.BackingServiceSelector != nil {
+		allErrs = append(allErrs, validateBackingServiceSelector(
+			*spec.BackingServiceSelector, fldPath.Child("backingServiceSelector"))...)
+	}
+	ids := make(map[string]bool)
+	if spec.BackingServiceSelector != nil && spec.BackingServiceSelector.ID != "" {
+		ids[spec.BackingServiceSelector.ID] = true
+	}
+	if spec.BackingServiceSelectors != nil {
+		for i, selector := range *spec.BackingServiceSelectors {
+			idxPath := fldPath.Child("backingServiceSelectors").Index(i)
+			allErrs = append(allErrs, validateBackingServiceSelector(selector, idxPath)...)
+			if selector.ID == "" {
+				continue
+			}
+			if ids[selector.ID] {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("id"), selector.ID))
+			}
+			ids[selector.ID] = true
+		}
+	}
+	return allErrs
+}
+
+// validateApplicationSelector checks the application selector GVR, and requires either a resource
+// name or a label selector to be informed, along with at most one of namespace and namespace
+// selector.
+func validateApplicationSelector(
+	selector v1alpha1.ApplicationSelector,
+	fldPath *field.Path,
+) field.ErrorList {
+	allErrs := validateGroupVersionResource(selector.GroupVersionResource, fldPath)
+	if selector.ResourceRef == "" && selector.LabelSelector == nil {
+		allErrs = append(allErrs, field.Required(fldPath, EmptyApplicationSelectorErr.Error()))
+	}
+	if selector.ResourceRef != "" {
+		allErrs = append(allErrs, validateDNS1123Subdomain(selector.ResourceRef, fldPath.Child("resourceRef"))...)
+	}
+	if selector.LabelSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(
+			selector.LabelSelector, fldPath.Child("labelSelector"))...)
+	}
+	if selector.Namespace != "" {
+		for _, msg := range validation.IsDNS1123Label(selector.Namespace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), selector.Namespace, msg))
+		}
+	}
+	if selector.NamespaceSelector != nil {
+		if selector.Namespace != "" {
+			allErrs = append(allErrs, field.Forbidden(
+				fldPath.Child("namespaceSelector"), "may not be informed along with namespace"))
+		}
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(
+			selector.NamespaceSelector, fldPath.Child("namespaceSelector"))...)
+	}
+	return allErrs
+}
+
+// validateApplicationSelectors checks both the single application selector and the list of
+// selectors, requiring the former when the latter is empty.
+func validateApplicationSelectors(
+	spec *v1alpha1.ServiceBindingRequestSpec,
+	fldPath *field.Path,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.ApplicationSelectors == nil || len(*spec.ApplicationSelectors) == 0 ||
+		isInformedApplicationSelector(spec.ApplicationSelector) {
+		allErrs = append(allErrs, validateApplicationSelector(
+			spec.ApplicationSelector, fldPath.Child("applicationSelector"))...)
+	}
+	if spec.ApplicationSelectors != nil {
+		for i, selector := range *spec.ApplicationSelectors {
+			allErrs = append(allErrs, validateApplicationSelector(
+				selector, fldPath.Child("applicationSelectors").Index(i))...)
+		}
+	}
+	return allErrs
+}
+
+// validateCustomEnvVar checks custom environment variable names, and whether their values can be
+// parsed as templates by CustomEnvParser.
+func validateCustomEnvVar(envVars []corev1.EnvVar, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, envVar := range envVars {
+		idxPath := fldPath.Index(i)
+		if envVar.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), ""))
+		} else {
+			for _, msg := range validation.IsEnvVarName(envVar.Name) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), envVar.Name, msg))
+			}
+		}
+		if _, err := parseCustomEnvTemplate(envVar.Value); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("value"), envVar.Value, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validateMountPath checks the informed mount path is absolute and does not contain '..' elements.
+func validateMountPath(mountPath string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if mountPath == "" {
+		return allErrs
+	}
+	if !strings.HasPrefix(mountPath, "/") {
+		allErrs = append(allErrs, field.Invalid(fldPath, mountPath, "must be an absolute path"))
+	}
+	for _, item := range strings.Split(mountPath, "/") {
+		if item == ".." {
+			allErrs = append(allErrs, field.Invalid(fldPath, mountPath, "must not contain '..'"))
+			break
+		}
+	}
+	return allErrs
+}
+
+// validateRestartStrategy checks the informed restart strategy is supported, when informed.
+func validateRestartStrategy(strategy v1alpha1.RestartStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch strategy {
+	case "", v1alpha1.RestartStrategyHashAnnotation, v1alpha1.RestartStrategyEnvVar, v1alpha1.RestartStrategyNone:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath, strategy, []string{
+			string(v1alpha1.RestartStrategyHashAnnotation),
+			string(v1alpha1.RestartStrategyEnvVar),
+			string(v1alpha1.RestartStrategyNone),
+		}))
+	}
+	return allErrs
+}
+
+// validateServiceBindingRequest check for unsupported settings in SBR, returning field level errors.
+func validateServiceBindingRequest(sbr *v1alpha1.ServiceBindingRequest) field.ErrorList {
+	specPath := field.NewPath("spec")
+	allErrs := validateBackingServiceSelectors(&sbr.Spec, specPath)
+	allErrs = append(allErrs, validateApplicationSelectors(&sbr.Spec, specPath)...)
+	allErrs = append(allErrs, validateCustomEnvVar(sbr.Spec.CustomEnvVar, specPath.Child("customEnvVar"))...)
+	allErrs = append(allErrs, validateMountPath(sbr.Spec.MountPathPrefix, specPath.Child("mountPathPrefix"))...)
+	allErrs = append(allErrs, validateRestartStrategy(sbr.Spec.RestartStrategy, specPath.Child("restartStrategy"))...)
+	return allErrs
+}
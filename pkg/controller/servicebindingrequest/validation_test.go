@@ -0,0 +1,184 @@
+package servicebindingrequest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestValidateServiceBindingRequest(t *testing.T) {
+	ns := "validation"
+	matchLabels := map[string]string{"connects-to": "database"}
+
+	// validSBR returns a fresh copy of a valid service binding request, to be modified by each case.
+	validSBR := func() *v1alpha1.ServiceBindingRequest {
+		return mocks.ServiceBindingRequestMock(ns, "sbr", nil, "db", "", deploymentsGVR, matchLabels)
+	}
+
+	tests := []struct {
+		name       string
+		modify     func(sbr *v1alpha1.ServiceBindingRequest)
+		wantFields []string
+	}{
+		{
+			name:   "valid",
+			modify: func(sbr *v1alpha1.ServiceBindingRequest) {},
+		},
+		{
+			name: "empty backing service selectors",
+			modify: func(sbr *v1alpha1.ServiceBindingRequest) {
+				sbr.Spec.BackingServiceSelector = nil
+				sbr.Spec.BackingServiceSelectors = &[]v1alpha1.BackingServiceSelector{}
+			},
+			wantFields: []string{"spec.backingServiceSelectors"},
+		},
+		{
+			name: "invalid backing service selector gvk",
+			modify: func(sbr *v1alpha1.ServiceBindingRequest) {
+				sbr.Spec.BackingServiceSelectors = &[]v1alpha1.BackingServiceSelector{{
+					GroupVersionKind: metav1.GroupVersionKind{Group: "Invalid_Group", Version: "", Kind: "Data base"},
+					ResourceRef:      "db",
+				}}
+			},
+			wantFields: []string{
+				"spec.backingServiceSelectors[0].group",
+				"spec.backingServiceSelectors[0].version",
+				"spec.backingServiceSelectors[0].kind",
+			},
+		},
+		{
+			name: "invalid backing service namespace and resource reference",
+			modify: func(sbr *v1alpha1.ServiceBindingRequest) {
+				namespace := "Invalid Namespace"
+				sbr.Spec.BackingServiceSelector.Namespace = &namespace
+				sbr.Spec.BackingServiceSelector.ResourceRef = ""
+			},
+			wantFields: []string{
+				"spec.backingServiceSelector.resourceRef",
+				"spec.backingServiceSelector.namespace",
+			},
+		},
+		{
+			name: "invalid backing service ids and prefix",
+			modify: func(sbr *v1alpha1.ServiceBindingRequest) {
+				prefix := "db-prefix"
+				sbr.Spec.BackingServiceSelector.ID = "db"
+				duplicate, invalid := *sbr.Spec.BackingServiceSelector, *sbr.Spec.BackingServiceSelector
+				invalid.ID = "1db"
+				invalid.EnvVarPrefix = &prefix
+				sbr.Spec.BackingServiceSelectors = &[]v1alpha1.BackingServiceSelector{duplicate, invalid}
+			},
+			wantFields: []string{
+				"spec.backingServiceSelectors[0].id",
+				"spec.backingServiceSelectors[1].id",
+				"spec.backingServiceSelectors[1].envVarPrefix",
+			},
+		},
+		{
+			name: "empty application selector",
+			modify: func(sbr *v1alpha1.ServiceBindingRequest) {
+				sbr.Spec.ApplicationSelector = v1alpha1.ApplicationSelector{}
+			},
+			wantFields: []string{
+				"spec.applicationSelector.version",
+				"spec.applicationSelector.resource",
+				"spec.applicationSelector",
+			},
+		},
+		{
+			name: "invalid application label selector",
+			modify: func(sbr *v1alpha1.ServiceBindingRequest) {
+				sbr.Spec.ApplicationSelector.LabelSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "environment",
+						Operator: metav1.LabelSelectorOpIn,
+					}},
+				}
+			},
+			wantFields: []string{"spec.applicationSelector.labelSelector.matchExpressions[0].values"},
+		},
+		{
+			name: "application namespace and namespace selector",
+			modify: func(sbr *v1alpha1.ServiceBindingRequest) {
+				sbr.Spec.ApplicationSelector.Namespace = "Invalid Namespace"
+				sbr.Spec.ApplicationSelector.NamespaceSelector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{"tenant": "true"},
+				}
+			},
+			wantFields: []string{
+				"spec.applicationSelector.namespace",
+				"spec.applicationSelector.namespaceSelector",
+			},
+		},
+		{
+			name: "application selectors",
+			modify: func(sbr *v1alpha1.ServiceBindingRequest) {
+				sbr.Spec.ApplicationSelectors = &[]v1alpha1.ApplicationSelector{
+					sbr.Spec.ApplicationSelector,
+					{GroupVersionResource: sbr.Spec.ApplicationSelector.GroupVersionResource},
+				}
+				sbr.Spec.ApplicationSelector = v1alpha1.ApplicationSelector{}
+			},
+			wantFields: []string{"spec.applicationSelectors[1]"},
+		},
+		{
+			name: "invalid custom env var",
+			modify: func(sbr *v1alpha1.ServiceBindingRequest) {
+				sbr.Spec.CustomEnvVar = []corev1.EnvVar{
+					{Name: "VALID", Value: "{{ .status.dbName }}"},
+					{Name: "", Value: "{{ .status.dbName"},
+				}
+			},
+			wantFields: []string{
+				"spec.customEnvVar[1].name",
+				"spec.customEnvVar[1].value",
+			},
+		},
+		{
+			name: "relative mount path",
+			modify: func(sbr *v1alpha1.ServiceBindingRequest) {
+				sbr.Spec.MountPathPrefix = "var/../data"
+			},
+			wantFields: []string{
+				"spec.mountPathPrefix",
+				"spec.mountPathPrefix",
+			},
+		},
+		{
+			name: "unsupported restart strategy",
+			modify: func(sbr *v1alpha1.ServiceBindingRequest) {
+				sbr.Spec.RestartStrategy = "Always"
+			},
+			wantFields: []string{"spec.restartStrategy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sbr := validSBR()
+			tt.modify(sbr)
+
+			errs := validateServiceBindingRequest(sbr)
+			fields := []string{}
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			require.ElementsMatch(t, tt.wantFields, fields, "errors: %v", errs)
+		})
+	}
+
+	t.Run("error types", func(t *testing.T) {
+		sbr := validSBR()
+		sbr.Spec.BackingServiceSelector = nil
+		errs := validateServiceBindingRequest(sbr)
+		require.Len(t, errs, 1)
+		require.Equal(t, field.ErrorTypeRequired, errs[0].Type)
+		require.Contains(t, errs[0].Detail, EmptyBackingServiceSelectorsErr.Error())
+	})
+}
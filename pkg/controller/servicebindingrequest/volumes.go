@@ -0,0 +1,119 @@
+package servicebindingrequest
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InvalidVolumeItemErr is returned when a volume mount descriptor item can't be parsed, for
+// instance due to an absolute sub path or a file mode not in octal notation.
+var InvalidVolumeItemErr = errors.New("volume mount item is invalid")
+
+// VolumeKey is an intermediary secret key projected as a file in the binding volume.
+type VolumeKey struct {
+	Key  string // intermediary secret key
+	Path string // path of the file, relative to the volume mount path
+	Mode *int32 // permission bits of the file, the volume default when not informed
+}
+
+// volumeItem is a secret or config map item declared by a volume mount descriptor, as in
+// "tls.key=certs/tls.key;mode=0400".
+type volumeItem struct {
+	key     string // secret or config map key, or "*" for all keys
+	subPath string // path of the file relative to the volume mount path, when informed
+	mode    *int32 // permission bits of the file, when informed
+}
+
+// parseVolumeItem parses the informed volume mount descriptor item, having the key optionally
+// followed by "=" and the sub path the file is projected to, and by ";mode=" and the file mode in
+// octal notation.
+func parseVolumeItem(s string) (*volumeItem, error) {
+	options := strings.Split(s, ";")
+	parts := strings.SplitN(options[0], "=", 2)
+	item := &volumeItem{key: parts[0]}
+	if item.key == "" {
+		return nil, fmt.Errorf("%w: '%s' has no key", InvalidVolumeItemErr, s)
+	}
+
+	if len(parts) == 2 {
+		subPath := parts[1]
+		if item.key == allItems {
+			return nil, fmt.Errorf("%w: '%s' has a sub path for all items", InvalidVolumeItemErr, s)
+		}
+		if subPath == "" || strings.HasPrefix(subPath, "/") {
+			return nil, fmt.Errorf("%w: '%s' sub path must be relative", InvalidVolumeItemErr, s)
+		}
+		for _, segment := range strings.Split(subPath, "/") {
+			if segment == ".." {
+				return nil, fmt.Errorf("%w: '%s' sub path must not contain '..'", InvalidVolumeItemErr, s)
+			}
+		}
+		item.subPath = subPath
+	}
+
+	for _, option := range options[1:] {
+		kv := strings.SplitN(option, "=", 2)
+		if len(kv) != 2 || kv[0] != "mode" {
+			return nil, fmt.Errorf("%w: '%s' has unknown option '%s'", InvalidVolumeItemErr, s, option)
+		}
+		mode, err := strconv.ParseInt(kv[1], 8, 32)
+		if err != nil || mode < 0 || mode > 0777 {
+			return nil, fmt.Errorf("%w: '%s' mode must be an octal value up to 0777", InvalidVolumeItemErr, s)
+		}
+		m := int32(mode)
+		item.mode = &m
+	}
+	return item, nil
+}
+
+// volumeKeys returns the volume keys of the informed items, given the intermediary secret keys the
+// secret or config map keys were stored as. Items not stored are skipped, so the volume never
+// refers to keys missing in the intermediary secret. Files are named after the intermediary
+// secret keys, unless a sub path is informed.
+func volumeKeys(items []*volumeItem, stored map[string]string) []VolumeKey {
+	keys := []VolumeKey{}
+	for _, item := range items {
+		if item.key == allItems {
+			names := make([]string, 0, len(stored))
+			for k := range stored {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+			for _, k := range names {
+				keys = append(keys, VolumeKey{Key: stored[k], Path: stored[k], Mode: item.mode})
+			}
+			continue
+		}
+		key, ok := stored[item.key]
+		if !ok {
+			continue
+		}
+		p := key
+		if item.subPath != "" {
+			p = item.subPath
+		}
+		keys = append(keys, VolumeKey{Key: key, Path: p, Mode: item.mode})
+	}
+	return keys
+}
+
+// appendVolumeKeys appends the informed volume keys, replacing the ones projected to the same path,
+// since paths must be unique in the volume.
+func appendVolumeKeys(keys []VolumeKey, added []VolumeKey) []VolumeKey {
+	for _, a := range added {
+		replaced := false
+		for i, k := range keys {
+			if k.Path == a.Path {
+				keys[i] = a
+				replaced = true
+			}
+		}
+		if !replaced {
+			keys = append(keys, a)
+		}
+	}
+	return keys
+}
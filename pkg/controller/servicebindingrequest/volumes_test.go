@@ -0,0 +1,83 @@
+package servicebindingrequest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVolumeItem(t *testing.T) {
+	mode := int32(0400)
+
+	tests := []struct {
+		name    string
+		item    string
+		want    *volumeItem
+		wantErr bool
+	}{
+		{name: "key", item: "tls.crt", want: &volumeItem{key: "tls.crt"}},
+		{name: "all items", item: "*", want: &volumeItem{key: allItems}},
+		{name: "sub path", item: "tls.crt=certs/tls.crt", want: &volumeItem{key: "tls.crt", subPath: "certs/tls.crt"}},
+		{
+			name: "sub path and mode",
+			item: "tls.key=certs/tls.key;mode=0400",
+			want: &volumeItem{key: "tls.key", subPath: "certs/tls.key", mode: &mode},
+		},
+		{name: "all items mode", item: "*;mode=0400", want: &volumeItem{key: allItems, mode: &mode}},
+		{name: "absolute sub path", item: "tls.crt=/etc/tls.crt", wantErr: true},
+		{name: "parent sub path", item: "tls.crt=../tls.crt", wantErr: true},
+		{name: "all items sub path", item: "*=certs", wantErr: true},
+		{name: "non octal mode", item: "tls.key;mode=0800", wantErr: true},
+		{name: "mode out of range", item: "tls.key;mode=01000", wantErr: true},
+		{name: "unknown option", item: "tls.key;owner=root", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseVolumeItem(tt.item)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.True(t, errors.Is(err, InvalidVolumeItemErr))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVolumeKeys(t *testing.T) {
+	mode := int32(0400)
+	stored := map[string]string{
+		"tls.crt": "DATABASE_SECRET_TLS_CRT",
+		"tls.key": "DATABASE_SECRET_TLS_KEY",
+	}
+
+	t.Run("declared items", func(t *testing.T) {
+		keys := volumeKeys([]*volumeItem{
+			{key: "tls.crt"},
+			{key: "tls.key", subPath: "certs/tls.key", mode: &mode},
+			{key: "missing"},
+		}, stored)
+		require.Equal(t, []VolumeKey{
+			{Key: "DATABASE_SECRET_TLS_CRT", Path: "DATABASE_SECRET_TLS_CRT"},
+			{Key: "DATABASE_SECRET_TLS_KEY", Path: "certs/tls.key", Mode: &mode},
+		}, keys)
+	})
+
+	t.Run("all items", func(t *testing.T) {
+		keys := volumeKeys([]*volumeItem{{key: allItems, mode: &mode}}, stored)
+		require.Equal(t, []VolumeKey{
+			{Key: "DATABASE_SECRET_TLS_CRT", Path: "DATABASE_SECRET_TLS_CRT", Mode: &mode},
+			{Key: "DATABASE_SECRET_TLS_KEY", Path: "DATABASE_SECRET_TLS_KEY", Mode: &mode},
+		}, keys)
+	})
+
+	t.Run("same path", func(t *testing.T) {
+		keys := appendVolumeKeys(
+			[]VolumeKey{{Key: "DATABASE_SECRET_TLS_CRT", Path: "tls"}},
+			[]VolumeKey{{Key: "DATABASE_SECRET_TLS_KEY", Path: "tls"}},
+		)
+		require.Equal(t, []VolumeKey{{Key: "DATABASE_SECRET_TLS_KEY", Path: "tls"}}, keys)
+	})
+}
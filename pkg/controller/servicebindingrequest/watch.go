@@ -1,8 +1,17 @@
 package servicebindingrequest
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+
 	"github.com/redhat-developer/service-binding-operator/pkg/log"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -52,3 +61,191 @@ func NewCreateWatchEventHandler(controller *SBRController) handler.EventHandler
 		ToRequests: &CSVToWatcherMapper{controller: controller},
 	}
 }
+
+// SBRToApplicationWatcher is a handler.EventHandler indexing the application selector of
+// ServiceBindingRequest objects, or of the resources translated into those, and creating a watch on
+// their application kind, so events on applications are mapped back to the SBRs selecting them. It
+// doesn't enqueue requests by itself.
+type SBRToApplicationWatcher struct {
+	controller *SBRController
+}
+
+// index adds the application selector of the informed SBR to the application index, making sure its
+// application kind is under watch.
+func (w *SBRToApplicationWatcher) index(obj runtime.Object) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		watchLog.Error(err, "Failed to convert object to unstructured")
+		return
+	}
+	sbr, _, err := w.controller.requests.FromUnstructured(&unstructured.Unstructured{Object: u})
+	if err != nil {
+		watchLog.Error(err, "Failed to convert object to ServiceBindingRequest")
+		return
+	}
+
+	log := watchLog.WithName("SBRToApplicationWatcher").WithValues(
+		"SBR.Namespace", sbr.GetNamespace(),
+		"SBR.Name", sbr.GetName(),
+	)
+
+	// kinds are informed in the same order as the selectors, being empty for the skipped ones
+	selectors := applicationSelectors(sbr)
+	gvks := make([]schema.GroupVersionKind, len(selectors))
+	for n, selector := range selectors {
+		gvr := schema.GroupVersionResource{
+			Group:    selector.Group,
+			Version:  selector.Version,
+			Resource: selector.Resource,
+		}
+		if gvr.Resource == "" {
+			log.Debug("Application resource is not informed, skipping", "Application.GVR", gvr)
+			continue
+		}
+		gvk, err := w.controller.AddWatchForApplicationGVR(gvr)
+		if err != nil {
+			log.Error(err, "Failed to create a watch on application resource", "Application.GVR", gvr)
+			continue
+		}
+		gvks[n] = gvk
+	}
+	if err = w.controller.applications.set(sbr, gvks...); err != nil {
+		log.Error(err, "Failed to index application selectors")
+	}
+}
+
+// Create indexes the created SBR.
+func (w *SBRToApplicationWatcher) Create(e event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	w.index(e.Object)
+}
+
+// Update indexes the updated SBR, since either its selector or its bound applications may change.
+func (w *SBRToApplicationWatcher) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	w.index(e.ObjectNew)
+}
+
+// Delete removes the deleted SBR from the index.
+func (w *SBRToApplicationWatcher) Delete(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	w.controller.applications.delete(types.NamespacedName{
+		Namespace: e.Meta.GetNamespace(),
+		Name:      e.Meta.GetName(),
+	})
+}
+
+// Generic indexes the informed SBR.
+func (w *SBRToApplicationWatcher) Generic(e event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	w.index(e.Object)
+}
+
+// NewApplicationWatchEventHandler creates a new instance of handler.EventHandler interface with
+// SBRToApplicationWatcher, to be employed on ServiceBindingRequest watches.
+func NewApplicationWatchEventHandler(controller *SBRController) handler.EventHandler {
+	return &SBRToApplicationWatcher{controller: controller}
+}
+
+// RESTMapperResetter is a handler.EventHandler resetting the RESTMapper on CustomResourceDefinition
+// events, so kinds declared by CRDs installed or changed after discovery information is cached are
+// resolved. It doesn't enqueue requests by itself.
+type RESTMapperResetter struct {
+	restMapper resettableRESTMapper
+}
+
+// Create resets the RESTMapper, since the created CRD declares new kinds.
+func (r *RESTMapperResetter) Create(_ event.CreateEvent, _ workqueue.RateLimitingInterface) {
+	r.restMapper.Reset()
+}
+
+// Update resets the RESTMapper when the CRD changes the kinds or versions it serves, including when
+// it becomes established; other changes don't affect discovery information.
+func (r *RESTMapperResetter) Update(e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	changed, err := crdServingChanged(e.ObjectOld, e.ObjectNew)
+	if err != nil {
+		watchLog.Error(err, "Comparing CRD versions, resetting RESTMapper")
+	}
+	if err != nil || changed {
+		r.restMapper.Reset()
+	}
+}
+
+// crdServingChanged returns whether the informed CRDs declare different names, scope or served
+// versions, or differ on the names accepted and whether they are established.
+func crdServingChanged(objOld, objNew runtime.Object) (bool, error) {
+	servingOld, err := crdServing(objOld)
+	if err != nil {
+		return false, err
+	}
+	servingNew, err := crdServing(objNew)
+	if err != nil {
+		return false, err
+	}
+	return !reflect.DeepEqual(servingOld, servingNew), nil
+}
+
+// crdServing extracts the parts of a CRD affecting discovery information: its names, scope, the
+// versions it declares with their served flags, and the names accepted and established condition
+// status, since discovery only serves the CRD once it's established.
+func crdServing(obj runtime.Object) (map[string]interface{}, error) {
+	if obj == nil {
+		return nil, errors.New("CRD object is not informed")
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	serving := make(map[string]interface{})
+	for _, field := range []string{"names", "scope", "version"} {
+		value, _, err := unstructured.NestedFieldNoCopy(u, "spec", field)
+		if err != nil {
+			return nil, err
+		}
+		serving[field] = value
+	}
+	versions, _, err := unstructured.NestedSlice(u, "spec", "versions")
+	if err != nil {
+		return nil, err
+	}
+	var served []interface{}
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected CRD version declaration: %v", v)
+		}
+		served = append(served, []interface{}{version["name"], version["served"]})
+	}
+	serving["versions"] = served
+
+	acceptedNames, _, err := unstructured.NestedFieldNoCopy(u, "status", "acceptedNames")
+	if err != nil {
+		return nil, err
+	}
+	serving["acceptedNames"] = acceptedNames
+	// conditions may be null when converted from a typed CRD without status
+	value, _, err := unstructured.NestedFieldNoCopy(u, "status", "conditions")
+	if err != nil {
+		return nil, err
+	}
+	conditions, _ := value.([]interface{})
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "Established" {
+			serving["established"] = condition["status"]
+		}
+	}
+	return serving, nil
+}
+
+// Delete resets the RESTMapper, since the kinds declared by the deleted CRD are not served anymore.
+func (r *RESTMapperResetter) Delete(_ event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	r.restMapper.Reset()
+}
+
+// Generic resets the RESTMapper.
+func (r *RESTMapperResetter) Generic(_ event.GenericEvent, _ workqueue.RateLimitingInterface) {
+	r.restMapper.Reset()
+}
+
+// NewCRDWatchEventHandler creates a new instance of handler.EventHandler interface with
+// RESTMapperResetter, to be employed on CustomResourceDefinition watches.
+func NewCRDWatchEventHandler(restMapper resettableRESTMapper) handler.EventHandler {
+	return &RESTMapperResetter{restMapper: restMapper}
+}
@@ -0,0 +1,191 @@
+package servicebindingrequest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/log"
+)
+
+// ValidatingWebhookPath is the path the ServiceBindingRequest validating webhook is served on.
+const ValidatingWebhookPath = "/validate-apps-openshift-io-v1alpha1-servicebindingrequest"
+
+// MutatingWebhookPath is the path the webhook recording the user requesting service bindings is
+// served on.
+const MutatingWebhookPath = "/mutate-apps-openshift-io-v1alpha1-servicebindingrequest"
+
+var (
+	webhookLog = log.NewLog("webhook")
+)
+
+// validatingHandler is the admission.Handler validating ServiceBindingRequest objects before they
+// are persisted.
+type validatingHandler struct {
+	decoder *admission.Decoder
+}
+
+// InjectDecoder injects the decoder configured with the manager's scheme.
+func (h *validatingHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// Handle validates created and updated ServiceBindingRequests, denying the request with field level
+// errors when the object is invalid.
+func (h *validatingHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	logger := webhookLog.WithValues(
+		"Request.Namespace", req.Namespace,
+		"Request.Name", req.Name,
+		"Request.Operation", req.Operation,
+	)
+
+	if req.Operation != admissionv1beta1.Create && req.Operation != admissionv1beta1.Update {
+		return admission.Allowed("")
+	}
+
+	sbr := &v1alpha1.ServiceBindingRequest{}
+	if err := h.decoder.Decode(req, sbr); err != nil {
+		logger.Error(err, "On decoding service-binding-request.")
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	// objects marked for deletion are not validated, otherwise invalid objects could never have their
+	// finalizers removed
+	if sbr.GetDeletionTimestamp() != nil {
+		return admission.Allowed("")
+	}
+
+	// updates leaving the spec untouched, such as the operator's metadata and status updates, are
+	// not validated either, since objects created before enabling the webhook may be invalid
+	if req.Operation == admissionv1beta1.Update {
+		old := &v1alpha1.ServiceBindingRequest{}
+		if err := h.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			logger.Error(err, "On decoding old service-binding-request.")
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if equality.Semantic.DeepEqual(old.Spec, sbr.Spec) {
+			return admission.Allowed("")
+		}
+	}
+
+	errs := validateServiceBindingRequest(sbr)
+	if len(errs) == 0 {
+		return admission.Allowed("")
+	}
+
+	logger.Debug("Denying invalid service-binding-request", "Errors", errs.ToAggregate().Error())
+	gk := v1alpha1.SchemeGroupVersion.WithKind(ServiceBindingRequestKind).GroupKind()
+	status := errors.NewInvalid(gk, sbr.GetName(), errs).ErrStatus
+	return admission.Response{
+		AdmissionResponse: admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		},
+	}
+}
+
+// AddValidatingWebhook registers the ServiceBindingRequest validating webhook on the manager's
+// webhook server.
+func AddValidatingWebhook(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(ValidatingWebhookPath, &webhook.Admission{Handler: &validatingHandler{}})
+	return nil
+}
+
+// requesterHandler is the admission.Handler recording the user requesting a service binding, whose
+// permissions are checked before backing services are read. It handles ServiceBindingRequests and
+// ServiceBindings alike.
+type requesterHandler struct {
+	decoder *admission.Decoder
+}
+
+// InjectDecoder injects the decoder configured with the manager's scheme.
+func (h *requesterHandler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// Handle records the user creating a service binding, or changing its spec, in the requester
+// annotation.
+func (h *requesterHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	logger := webhookLog.WithValues(
+		"Request.Namespace", req.Namespace,
+		"Request.Name", req.Name,
+		"Request.Operation", req.Operation,
+	)
+
+	if req.Operation != admissionv1beta1.Create && req.Operation != admissionv1beta1.Update {
+		return admission.Allowed("")
+	}
+
+	obj, err := h.mutate(req)
+	if err != nil {
+		logger.Error(err, "On decoding service binding.")
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	mutated, err := json.Marshal(obj.Object)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, mutated)
+}
+
+// mutate returns the requested object annotated with its requester. Updates leaving the spec
+// untouched keep the annotation as it was, so it can't be forged or removed.
+func (h *requesterHandler) mutate(req admission.Request) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	if err := h.decoder.Decode(req, obj); err != nil {
+		return nil, err
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	old := &unstructured.Unstructured{}
+	specChanged := true
+	if req.Operation == admissionv1beta1.Update {
+		if err := h.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return nil, err
+		}
+		specChanged = !equality.Semantic.DeepEqual(old.Object["spec"], obj.Object["spec"])
+	}
+
+	if specChanged {
+		requester, err := json.Marshal(req.UserInfo)
+		if err != nil {
+			return nil, err
+		}
+		annotations[requesterAnnotation] = string(requester)
+	} else if requester, found := old.GetAnnotations()[requesterAnnotation]; found {
+		annotations[requesterAnnotation] = requester
+	} else {
+		delete(annotations, requesterAnnotation)
+	}
+	obj.SetAnnotations(annotations)
+	return obj, nil
+}
+
+// AddMutatingWebhook registers the webhook recording the user requesting service bindings on the
+// manager's webhook server.
+func AddMutatingWebhook(mgr manager.Manager) error {
+	mgr.GetWebhookServer().Register(MutatingWebhookPath, &webhook.Admission{Handler: &requesterHandler{}})
+	return nil
+}
+
+// blank assignment to verify that validatingHandler implements admission.Handler
+var _ admission.Handler = &validatingHandler{}
+
+// blank assignment to verify that requesterHandler implements admission.Handler
+var _ admission.Handler = &requesterHandler{}
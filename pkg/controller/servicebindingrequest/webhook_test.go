@@ -0,0 +1,202 @@
+package servicebindingrequest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestValidatingHandlerHandle(t *testing.T) {
+	ns := "webhook"
+	matchLabels := map[string]string{"connects-to": "database"}
+
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.SchemeBuilder.AddToScheme(s))
+	decoder, err := admission.NewDecoder(s)
+	require.NoError(t, err)
+
+	handler := &validatingHandler{}
+	require.NoError(t, handler.InjectDecoder(decoder))
+
+	// buildRequest serializes the informed SBR in an admission request for the given operation.
+	buildRequest := func(op admissionv1beta1.Operation, sbr *v1alpha1.ServiceBindingRequest) admission.Request {
+		sbr.TypeMeta = metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       ServiceBindingRequestKind,
+		}
+		raw, err := json.Marshal(sbr)
+		require.NoError(t, err)
+		return admission.Request{
+			AdmissionRequest: admissionv1beta1.AdmissionRequest{
+				Operation: op,
+				Namespace: sbr.GetNamespace(),
+				Name:      sbr.GetName(),
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		}
+	}
+
+	// buildUpdateRequest serializes the informed SBRs in an update admission request.
+	buildUpdateRequest := func(old, sbr *v1alpha1.ServiceBindingRequest) admission.Request {
+		req := buildRequest(admissionv1beta1.Update, sbr)
+		oldReq := buildRequest(admissionv1beta1.Update, old)
+		req.OldObject = oldReq.Object
+		return req
+	}
+
+	t.Run("valid create", func(t *testing.T) {
+		sbr := mocks.ServiceBindingRequestMock(ns, "valid", nil, "db", "", deploymentsGVR, matchLabels)
+		resp := handler.Handle(context.TODO(), buildRequest(admissionv1beta1.Create, sbr))
+		require.True(t, resp.Allowed)
+	})
+
+	t.Run("invalid update", func(t *testing.T) {
+		sbr := mocks.ServiceBindingRequestMock(ns, "invalid", nil, "db", "", deploymentsGVR, nil)
+		old := sbr.DeepCopy()
+		sbr.Spec.BackingServiceSelector = nil
+		sbr.Spec.ApplicationSelector.LabelSelector = nil
+
+		resp := handler.Handle(context.TODO(), buildUpdateRequest(old, sbr))
+		require.False(t, resp.Allowed)
+		require.NotNil(t, resp.Result)
+		require.Equal(t, metav1.StatusReasonInvalid, resp.Result.Reason)
+		require.NotNil(t, resp.Result.Details)
+
+		fields := []string{}
+		for _, cause := range resp.Result.Details.Causes {
+			fields = append(fields, cause.Field)
+		}
+		require.ElementsMatch(t, []string{"spec.backingServiceSelectors", "spec.applicationSelector"}, fields)
+	})
+
+	t.Run("invalid update leaving spec untouched", func(t *testing.T) {
+		sbr := mocks.ServiceBindingRequestMock(ns, "invalid", nil, "db", "", deploymentsGVR, nil)
+		sbr.Spec.BackingServiceSelector = nil
+		old := sbr.DeepCopy()
+		sbr.SetFinalizers([]string{Finalizer})
+
+		resp := handler.Handle(context.TODO(), buildUpdateRequest(old, sbr))
+		require.True(t, resp.Allowed)
+	})
+
+	t.Run("invalid marked for deletion", func(t *testing.T) {
+		sbr := mocks.ServiceBindingRequestMock(ns, "deleted", nil, "db", "", deploymentsGVR, nil)
+		sbr.Spec.BackingServiceSelector = nil
+		now := metav1.Now()
+		sbr.SetDeletionTimestamp(&now)
+
+		resp := handler.Handle(context.TODO(), buildRequest(admissionv1beta1.Update, sbr))
+		require.True(t, resp.Allowed)
+	})
+
+	t.Run("undecodable object", func(t *testing.T) {
+		req := admission.Request{
+			AdmissionRequest: admissionv1beta1.AdmissionRequest{
+				Operation: admissionv1beta1.Create,
+				Object:    runtime.RawExtension{Raw: []byte("{")},
+			},
+		}
+		resp := handler.Handle(context.TODO(), req)
+		require.False(t, resp.Allowed)
+		require.Equal(t, int32(http.StatusBadRequest), resp.Result.Code)
+	})
+}
+
+func TestRequesterHandlerHandle(t *testing.T) {
+	ns := "webhook"
+	developer := authenticationv1.UserInfo{Username: "developer", Groups: []string{"developers"}}
+	admin := authenticationv1.UserInfo{Username: "admin"}
+
+	s := runtime.NewScheme()
+	require.NoError(t, v1alpha1.SchemeBuilder.AddToScheme(s))
+	decoder, err := admission.NewDecoder(s)
+	require.NoError(t, err)
+
+	handler := &requesterHandler{}
+	require.NoError(t, handler.InjectDecoder(decoder))
+
+	// marshal serializes the informed SBR.
+	marshal := func(sbr *v1alpha1.ServiceBindingRequest) []byte {
+		raw, err := json.Marshal(sbr)
+		require.NoError(t, err)
+		return raw
+	}
+
+	// handle handles the request of the informed user, returning the recorded requester annotation.
+	handle := func(
+		op admissionv1beta1.Operation,
+		user authenticationv1.UserInfo,
+		old, sbr *v1alpha1.ServiceBindingRequest,
+	) (string, bool) {
+		req := admission.Request{
+			AdmissionRequest: admissionv1beta1.AdmissionRequest{
+				Operation: op,
+				UserInfo:  user,
+				Object:    runtime.RawExtension{Raw: marshal(sbr)},
+			},
+		}
+		if old != nil {
+			req.OldObject = runtime.RawExtension{Raw: marshal(old)}
+		}
+		resp := handler.Handle(context.TODO(), req)
+		require.True(t, resp.Allowed)
+
+		mutated, err := handler.mutate(req)
+		require.NoError(t, err)
+		requester, found := mutated.GetAnnotations()[requesterAnnotation]
+		return requester, found
+	}
+
+	sbr := mocks.ServiceBindingRequestMock(ns, "sbr", nil, "db", "app", deploymentsGVR, nil)
+	recorded := &v1alpha1.ServiceBindingRequest{}
+	recorded.SetAnnotations(map[string]string{requesterAnnotation: string(mustMarshal(t, developer))})
+
+	t.Run("create", func(t *testing.T) {
+		forged := sbr.DeepCopy()
+		forged.SetAnnotations(map[string]string{requesterAnnotation: string(mustMarshal(t, admin))})
+		requester, found := handle(admissionv1beta1.Create, developer, nil, forged)
+		require.True(t, found)
+		require.Equal(t, string(mustMarshal(t, developer)), requester)
+	})
+
+	t.Run("update keeping the spec", func(t *testing.T) {
+		old := sbr.DeepCopy()
+		old.SetAnnotations(recorded.GetAnnotations())
+		requester, found := handle(admissionv1beta1.Update, admin, old, sbr.DeepCopy())
+		require.True(t, found)
+		require.Equal(t, string(mustMarshal(t, developer)), requester)
+
+		forged := sbr.DeepCopy()
+		forged.SetAnnotations(map[string]string{requesterAnnotation: string(mustMarshal(t, admin))})
+		_, found = handle(admissionv1beta1.Update, developer, sbr.DeepCopy(), forged)
+		require.False(t, found)
+	})
+
+	t.Run("update changing the spec", func(t *testing.T) {
+		old := sbr.DeepCopy()
+		old.SetAnnotations(recorded.GetAnnotations())
+		changed := old.DeepCopy()
+		changed.Spec.ApplicationSelector.ResourceRef = "other"
+		requester, found := handle(admissionv1beta1.Update, admin, old, changed)
+		require.True(t, found)
+		require.Equal(t, string(mustMarshal(t, admin)), requester)
+	})
+}
+
+// mustMarshal serializes the informed value.
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	raw, err := json.Marshal(v)
+	require.NoError(t, err)
+	return raw
+}
@@ -0,0 +1,118 @@
+package servicebindingrequest
+
+import (
+	"errors"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+)
+
+// WorkloadAdapter locates the elements of the pod spec the binding is projected into in application
+// workloads of a given kind.
+type WorkloadAdapter interface {
+	// ContainersPath returns the path of the containers.
+	ContainersPath() []string
+	// VolumesPath returns the path of the volumes.
+	VolumesPath() []string
+	// PodAnnotationsPath returns the path of the annotations of the pods created from the workload.
+	PodAnnotationsPath() []string
+}
+
+// pathAdapter is a WorkloadAdapter based on fixed paths.
+type pathAdapter struct {
+	containers     []string // path of the containers
+	volumes        []string // path of the volumes
+	podAnnotations []string // path of the pod annotations
+}
+
+// ContainersPath returns the path of the containers.
+func (a *pathAdapter) ContainersPath() []string {
+	return a.containers
+}
+
+// VolumesPath returns the path of the volumes.
+func (a *pathAdapter) VolumesPath() []string {
+	return a.volumes
+}
+
+// PodAnnotationsPath returns the path of the pod annotations.
+func (a *pathAdapter) PodAnnotationsPath() []string {
+	return a.podAnnotations
+}
+
+// podSpecAdapter returns the adapter of workloads having the pod spec in the informed path, and the
+// pod metadata as its sibling.
+func podSpecAdapter(podSpecPath ...string) WorkloadAdapter {
+	return &pathAdapter{
+		containers:     appendPath(podSpecPath, "containers"),
+		volumes:        appendPath(podSpecPath, "volumes"),
+		podAnnotations: appendPath(podSpecPath[:len(podSpecPath)-1], "metadata", "annotations"),
+	}
+}
+
+var (
+	// podTemplateAdapter is the adapter of workloads having a pod template in "spec.template", such
+	// as Deployments, DeploymentConfigs, StatefulSets, DaemonSets, Jobs and Knative Services.
+	podTemplateAdapter = &pathAdapter{
+		containers:     containersPath,
+		volumes:        volumesPath,
+		podAnnotations: podAnnotationsPath,
+	}
+
+	// workloadAdapters are the built-in adapters of workload kinds not having a pod template in
+	// "spec.template".
+	workloadAdapters = map[schema.GroupKind]WorkloadAdapter{
+		{Group: "batch", Kind: "CronJob"}: podSpecAdapter("spec", "jobTemplate", "spec", "template", "spec"),
+	}
+
+	// immutableWorkloads are the workload kinds whose containers and volumes can't be changed once
+	// created, which therefore can't be bound.
+	immutableWorkloads = map[schema.GroupKind]bool{
+		{Group: "", Kind: "Pod"}: true,
+	}
+
+	// ImmutableWorkloadErr is returned when a selected workload can't be changed once created.
+	ImmutableWorkloadErr = errors.New("workload can't be bound once created")
+)
+
+// bindingPathAdapter returns the adapter of workloads having containers and volumes in the informed
+// binding path. Volumes are the sibling of containers by default, and pod annotations are found in
+// the pod metadata when containers are in a pod spec, otherwise in the workload metadata.
+func bindingPathAdapter(bindingPath *v1alpha1.BindingPath) WorkloadAdapter {
+	containers := strings.Split(bindingPath.ContainersPath, ".")
+	parent := containers[:len(containers)-1]
+
+	volumes := appendPath(parent, "volumes")
+	if bindingPath.VolumesPath != "" {
+		volumes = strings.Split(bindingPath.VolumesPath, ".")
+	}
+
+	podAnnotations := []string{"metadata", "annotations"}
+	if len(parent) > 0 && parent[len(parent)-1] == "spec" {
+		podAnnotations = appendPath(parent[:len(parent)-1], "metadata", "annotations")
+	}
+
+	return &pathAdapter{containers: containers, volumes: volumes, podAnnotations: podAnnotations}
+}
+
+// workloadAdapterFor returns the adapter of the informed workload, based on the informed binding
+// path when declared, otherwise on the workload kind.
+func workloadAdapterFor(obj *unstructured.Unstructured, bindingPath *v1alpha1.BindingPath) WorkloadAdapter {
+	if bindingPath != nil && bindingPath.ContainersPath != "" {
+		return bindingPathAdapter(bindingPath)
+	}
+	if adapter, ok := workloadAdapters[obj.GroupVersionKind().GroupKind()]; ok {
+		return adapter
+	}
+	return podTemplateAdapter
+}
+
+// appendPath returns a new path, made of the informed base path followed by the informed fields.
+func appendPath(base []string, fields ...string) []string {
+	result := make([]string, 0, len(base)+len(fields))
+	result = append(result, base...)
+	return append(result, fields...)
+}
@@ -0,0 +1,195 @@
+package servicebindingrequest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/test/mocks"
+)
+
+func TestWorkloadAdapterFor(t *testing.T) {
+	workload := func(apiVersion, kind string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		return u
+	}
+
+	tests := []struct {
+		name               string
+		obj                *unstructured.Unstructured
+		bindingPath        *v1alpha1.BindingPath
+		wantContainers     string
+		wantVolumes        string
+		wantPodAnnotations string
+	}{
+		{
+			name:               "pod template",
+			obj:                workload("apps/v1", "Deployment"),
+			wantContainers:     "spec.template.spec.containers",
+			wantVolumes:        "spec.template.spec.volumes",
+			wantPodAnnotations: "spec.template.metadata.annotations",
+		},
+		{
+			name:               "cronjob",
+			obj:                workload("batch/v1beta1", "CronJob"),
+			wantContainers:     "spec.jobTemplate.spec.template.spec.containers",
+			wantVolumes:        "spec.jobTemplate.spec.template.spec.volumes",
+			wantPodAnnotations: "spec.jobTemplate.spec.template.metadata.annotations",
+		},
+		{
+			name:               "binding path",
+			obj:                workload("apps/v1", "Deployment"),
+			bindingPath:        &v1alpha1.BindingPath{ContainersPath: "spec.runtime.template.spec.containers"},
+			wantContainers:     "spec.runtime.template.spec.containers",
+			wantVolumes:        "spec.runtime.template.spec.volumes",
+			wantPodAnnotations: "spec.runtime.template.metadata.annotations",
+		},
+		{
+			name: "binding path with volumes",
+			obj:  workload("example.com/v1", "App"),
+			bindingPath: &v1alpha1.BindingPath{
+				ContainersPath: "spec.workers",
+				VolumesPath:    "spec.storage.volumes",
+			},
+			wantContainers:     "spec.workers",
+			wantVolumes:        "spec.storage.volumes",
+			wantPodAnnotations: "metadata.annotations",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapter := workloadAdapterFor(tt.obj, tt.bindingPath)
+			require.Equal(t, tt.wantContainers, strings.Join(adapter.ContainersPath(), "."))
+			require.Equal(t, tt.wantVolumes, strings.Join(adapter.VolumesPath(), "."))
+			require.Equal(t, tt.wantPodAnnotations, strings.Join(adapter.PodAnnotationsPath(), "."))
+		})
+	}
+}
+
+func TestBinderBindingPath(t *testing.T) {
+	ns := "workload"
+	name := "sbr"
+	gvr := schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "apps"}
+
+	f := mocks.NewFake(t, ns)
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "App",
+		"metadata":   map[string]interface{}{"namespace": ns, "name": "app"},
+		"spec": map[string]interface{}{
+			"workers": []interface{}{
+				map[string]interface{}{"name": "worker", "image": "busybox:latest"},
+			},
+		},
+	}}
+	f.AddMockResource(obj)
+	sbr := f.AddMockedServiceBindingRequest(name, nil, "ref", "app", gvr, nil)
+	sbr.Spec.ApplicationSelector.BindingPath = &v1alpha1.BindingPath{ContainersPath: "spec.workers"}
+
+	binder := NewBinder(context.TODO(), f.FakeClient(), f.FakeDynClient(), f.FakeRESTMapper(), sbr, []VolumeKey{})
+	list, err := binder.search(binder.sbr.Spec.ApplicationSelector)
+	require.NoError(t, err)
+	updatedObjects, err := binder.update(list)
+	require.NoError(t, err)
+	require.Len(t, updatedObjects, 1)
+	bound := updatedObjects[0]
+
+	workers, _, err := unstructured.NestedSlice(bound.Object, "spec", "workers")
+	require.NoError(t, err)
+	c, err := binder.containerFromUnstructured(workers[0])
+	require.NoError(t, err)
+	require.True(t, hasEnvFromSecret(c.EnvFrom, name))
+
+	// the binding path is recorded, so drifts are found without the service binding request
+	injected, found, err := readInjections(bound, binder.namespacedName())
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, sbr.Spec.ApplicationSelector.BindingPath, injected.BindingPath)
+	missing, err := missingInjections(bound, binder.namespacedName().String(), injected)
+	require.NoError(t, err)
+	require.Empty(t, missing)
+
+	require.NoError(t, binder.remove(&unstructured.UnstructuredList{
+		Items: []unstructured.Unstructured{*bound.DeepCopy()},
+	}))
+	unbound := &unstructured.Unstructured{}
+	unbound.SetGroupVersionKind(bound.GroupVersionKind())
+	require.NoError(t, binder.client.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: "app"}, unbound))
+	workers, _, err = unstructured.NestedSlice(unbound.Object, "spec", "workers")
+	require.NoError(t, err)
+	c, err = binder.containerFromUnstructured(workers[0])
+	require.NoError(t, err)
+	require.Empty(t, c.EnvFrom)
+	require.Empty(t, unbound.GetAnnotations())
+}
+
+// TestBinderBindImmutableWorkload checks Pods, whose spec can't be changed once created, are left
+// unbound, failing the binding only when no other workload is selected.
+func TestBinderBindImmutableWorkload(t *testing.T) {
+	ns := "workload"
+	matchLabels := map[string]string{"connects-to": "database"}
+	f := mocks.NewFake(t, ns)
+	pod := f.AddMockedUnstructuredPod("app", matchLabels)
+	sbr := f.AddMockedServiceBindingRequest("sbr", nil, "ref", "", corev1.SchemeGroupVersion.WithResource("pods"), matchLabels)
+
+	fakeClient := f.FakeClient()
+	binder := NewBinder(context.TODO(), fakeClient, f.FakeDynClient(), f.FakeRESTMapper(), sbr, []VolumeKey{})
+	updatedObjects, err := binder.Bind()
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ImmutableWorkloadErr))
+	require.Empty(t, updatedObjects)
+	require.Equal(t, ImmutableWorkload, (&ServiceBinder{}).reason(err))
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(pod.GroupVersionKind())
+	require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: pod.GetName()}, u))
+	require.Equal(t, pod.Object["spec"], u.Object["spec"])
+	require.Empty(t, u.GetAnnotations())
+}
+
+// TestBinderBindImmutableWorkloadSkipped checks Pods selected along with other workloads are
+// skipped and reported, while the other workloads are bound.
+func TestBinderBindImmutableWorkloadSkipped(t *testing.T) {
+	ns := "workload"
+	matchLabels := map[string]string{"connects-to": "database"}
+	f := mocks.NewFake(t, ns)
+	pod := f.AddMockedUnstructuredPod("pod", matchLabels)
+	f.AddMockedUnstructuredDeployment("app", matchLabels)
+	sbr := mocks.ServiceBindingRequestMock(ns, "sbr", nil, "ref", "", deploymentsGVR, matchLabels)
+	sbr.Spec.ApplicationSelectors = &[]v1alpha1.ApplicationSelector{{
+		GroupVersionResource: metav1.GroupVersionResource(corev1.SchemeGroupVersion.WithResource("pods")),
+		LabelSelector:        sbr.Spec.ApplicationSelector.LabelSelector,
+	}}
+
+	fakeClient := f.FakeClient()
+	binder := NewBinder(context.TODO(), fakeClient, f.FakeDynClient(), f.FakeRESTMapper(), sbr, []VolumeKey{})
+	updatedObjects, err := binder.Bind()
+	require.NoError(t, err)
+	require.Len(t, updatedObjects, 1)
+	require.Equal(t, "Deployment", updatedObjects[0].GetKind())
+	require.Equal(t, []string{"Pod 'pod' in namespace 'workload'"}, binder.skipped)
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(pod.GroupVersionKind())
+	require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: ns, Name: pod.GetName()}, u))
+	require.Equal(t, pod.Object["spec"], u.Object["spec"])
+
+	recorder := record.NewFakeRecorder(1)
+	sb := &ServiceBinder{Binder: binder, SBR: sbr, Recorder: recorder}
+	sb.recordSkipped()
+	event := <-recorder.Events
+	require.Contains(t, event, ImmutableWorkload)
+	require.Contains(t, event, "Pod 'pod'")
+}
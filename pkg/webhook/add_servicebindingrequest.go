@@ -0,0 +1,14 @@
+package webhook
+
+import (
+	"github.com/redhat-developer/service-binding-operator/pkg/controller/servicebindingrequest"
+)
+
+func init() {
+	// AddToManagerFuncs is a list of functions to create webhooks and add them to a manager.
+	AddToManagerFuncs = append(
+		AddToManagerFuncs,
+		servicebindingrequest.AddValidatingWebhook,
+		servicebindingrequest.AddMutatingWebhook,
+	)
+}
@@ -8,7 +8,10 @@ import (
 	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -21,14 +24,17 @@ import (
 	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
 
 	v1alpha1 "github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3"
+	"github.com/redhat-developer/service-binding-operator/pkg/converter"
 )
 
 // Fake defines all the elements to fake a kubernetes api client.
 type Fake struct {
-	t    *testing.T       // testing instance
-	ns   string           // namespace
-	S    *runtime.Scheme  // runtime client scheme
-	objs []runtime.Object // all fake objects
+	t          *testing.T          // testing instance
+	ns         string              // namespace
+	S          *runtime.Scheme     // runtime client scheme
+	objs       []runtime.Object    // all fake objects
+	crdVersion schema.GroupVersion // CRD API version served by the fake cluster
 }
 
 // AddMockedServiceBindingRequest add mocked object from ServiceBindingRequestMock.
@@ -75,6 +81,21 @@ func (f *Fake) AddMockedUnstructuredServiceBindingRequest(
 	return sbr
 }
 
+// AddMockedUnstructuredServiceBinding creates a mock servicebinding.io ServiceBinding object.
+func (f *Fake) AddMockedUnstructuredServiceBinding(
+	name string,
+	backingServiceResourceRef string,
+	applicationResourceRef string,
+	applicationGVK schema.GroupVersionKind,
+	matchLabels map[string]string,
+) *unstructured.Unstructured {
+	f.S.AddKnownTypes(v1alpha3.SchemeGroupVersion, &v1alpha3.ServiceBinding{})
+	sb, err := UnstructuredServiceBindingMock(f.ns, name, backingServiceResourceRef, applicationResourceRef, applicationGVK, matchLabels)
+	require.NoError(f.t, err)
+	f.objs = append(f.objs, sb)
+	return sb
+}
+
 // AddMockedUnstructuredCSV add mocked unstructured CSV.
 func (f *Fake) AddMockedUnstructuredCSV(name string) {
 	require.NoError(f.t, olmv1alpha1.AddToScheme(f.S))
@@ -124,13 +145,31 @@ func (f *Fake) AddMockedUnstructuredDatabaseCR(ref string) {
 	f.objs = append(f.objs, d)
 }
 
+// AddMockedUnstructuredProvisionedService adds mocked object from UnstructuredProvisionedServiceMock.
+func (f *Fake) AddMockedUnstructuredProvisionedService(ref, secretName string) *unstructured.Unstructured {
+	require.NoError(f.t, pgapis.AddToScheme(f.S))
+	u, err := UnstructuredProvisionedServiceMock(f.ns, ref, secretName)
+	require.NoError(f.t, err)
+	f.objs = append(f.objs, u)
+	return u
+}
+
+// AddMockedUnstructuredBindableService adds mocked object from BindableServiceMock.
+func (f *Fake) AddMockedUnstructuredBindableService(name, version string) *unstructured.Unstructured {
+	u, err := converter.ToUnstructured(BindableServiceMock(name, version))
+	require.NoError(f.t, err)
+	f.objs = append(f.objs, u)
+	return u
+}
+
 // AddMockedUnstructuredDeploymentConfig adds mocked object from UnstructuredDeploymentConfigMock.
-func (f *Fake) AddMockedUnstructuredDeploymentConfig(name string, matchLabels map[string]string) {
+func (f *Fake) AddMockedUnstructuredDeploymentConfig(name string, matchLabels map[string]string) *unstructured.Unstructured {
 	require.Nil(f.t, ocav1.AddToScheme(f.S))
 	d, err := UnstructuredDeploymentConfigMock(f.ns, name, matchLabels)
 	require.Nil(f.t, err)
 	f.S.AddKnownTypes(ocav1.SchemeGroupVersion, &ocav1.DeploymentConfig{})
 	f.objs = append(f.objs, d)
+	return d
 }
 
 // AddMockedUnstructuredDeployment add mocked object from UnstructuredDeploymentMock.
@@ -144,12 +183,42 @@ func (f *Fake) AddMockedUnstructuredDeployment(name string, matchLabels map[stri
 }
 
 // AddMockedUnstructuredKnativeService add mocked object from UnstructuredKnativeService.
-func (f *Fake) AddMockedUnstructuredKnativeService(name string, matchLabels map[string]string) {
+func (f *Fake) AddMockedUnstructuredKnativeService(name string, matchLabels map[string]string) *unstructured.Unstructured {
 	require.NoError(f.t, knativev1.AddToScheme(f.S))
 	d, err := UnstructuredKnativeServiceMock(f.ns, name, matchLabels)
 	require.NoError(f.t, err)
 	f.S.AddKnownTypes(knativev1.SchemeGroupVersion, &knativev1.Service{})
 	f.objs = append(f.objs, d)
+	return d
+}
+
+// AddMockedUnstructuredCronJob add mocked object from UnstructuredCronJobMock.
+func (f *Fake) AddMockedUnstructuredCronJob(name string, matchLabels map[string]string) *unstructured.Unstructured {
+	require.NoError(f.t, batchv1beta1.AddToScheme(f.S))
+	c, err := UnstructuredCronJobMock(f.ns, name, matchLabels)
+	require.NoError(f.t, err)
+	f.objs = append(f.objs, c)
+	return c
+}
+
+// AddMockedUnstructuredPod add mocked object from UnstructuredPodMock.
+func (f *Fake) AddMockedUnstructuredPod(name string, matchLabels map[string]string) *unstructured.Unstructured {
+	p, err := UnstructuredPodMock(f.ns, name, matchLabels)
+	require.NoError(f.t, err)
+	f.objs = append(f.objs, p)
+	return p
+}
+
+// AddMockedUnstructuredNamespace add mocked object from UnstructuredNamespaceMock.
+func (f *Fake) AddMockedUnstructuredNamespace(
+	name string,
+	labels map[string]string,
+	annotations map[string]string,
+) *unstructured.Unstructured {
+	ns, err := UnstructuredNamespaceMock(name, labels, annotations)
+	require.NoError(f.t, err)
+	f.objs = append(f.objs, ns)
+	return ns
 }
 
 func (f *Fake) AddMockedUnstructuredDatabaseCRD() *unstructured.Unstructured {
@@ -157,6 +226,16 @@ func (f *Fake) AddMockedUnstructuredDatabaseCRD() *unstructured.Unstructured {
 	c, err := UnstructuredDatabaseCRDMock(f.ns)
 	require.NoError(f.t, err)
 	f.S.AddKnownTypes(apiextensionv1beta1.SchemeGroupVersion, &apiextensionv1beta1.CustomResourceDefinition{})
+	f.crdVersion = apiextensionv1beta1.SchemeGroupVersion
+	f.objs = append(f.objs, c)
+	return c
+}
+
+func (f *Fake) AddMockedUnstructuredDatabaseCRDV1() *unstructured.Unstructured {
+	require.NoError(f.t, apiextensionv1.AddToScheme(f.S))
+	c, err := UnstructuredDatabaseCRDV1Mock()
+	require.NoError(f.t, err)
+	f.crdVersion = apiextensionv1.SchemeGroupVersion
 	f.objs = append(f.objs, c)
 	return c
 }
@@ -201,6 +280,23 @@ func (f *Fake) FakeDynClient() *fakedynamic.FakeDynamicClient {
 	return fakedynamic.NewSimpleDynamicClient(f.S, f.objs...)
 }
 
+// FakeRESTMapper returns a RESTMapper aware of all kinds registered in the fake scheme, guessing
+// their resources. CRDs are preferably mapped to the version of the last mocked CRD.
+func (f *Fake) FakeRESTMapper() meta.RESTMapper {
+	versions := f.S.PrioritizedVersionsAllGroups()
+	if !f.crdVersion.Empty() {
+		versions = append([]schema.GroupVersion{f.crdVersion}, versions...)
+	}
+	mapper := meta.NewDefaultRESTMapper(versions)
+	for gvk := range f.S.AllKnownTypes() {
+		if gvk.Version == runtime.APIVersionInternal {
+			continue
+		}
+		mapper.Add(gvk, meta.RESTScopeNamespace)
+	}
+	return mapper
+}
+
 // NewFake instantiate Fake type.
 func NewFake(t *testing.T, ns string) *Fake {
 	return &Fake{t: t, ns: ns, S: scheme.Scheme}
@@ -11,7 +11,10 @@ import (
 	olmv1alpha1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
 	olminstall "github.com/operator-framework/operator-lifecycle-manager/pkg/controller/install"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -20,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/apis/apps/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/apis/servicebinding/v1alpha3"
 	"github.com/redhat-developer/service-binding-operator/pkg/converter"
 
 	knativev1 "knative.dev/serving/pkg/apis/serving/v1"
@@ -132,6 +136,45 @@ func UnstructuredDatabaseCRDMock(ns string) (*unstructured.Unstructured, error)
 	return converter.ToUnstructured(&crd)
 }
 
+// DatabaseCRDV1Mock returns the database CRD as served by apiextensions.k8s.io/v1, declaring its
+// versions in "spec.versions".
+func DatabaseCRDV1Mock() apiextensionv1.CustomResourceDefinition {
+	CRDPlural := "databases"
+	FullCRDName := CRDPlural + "." + CRDName
+	annotations := map[string]string{
+		"servicebindingoperator.redhat.io/status.dbCredentials-password": "binding:env:object:secret",
+		"servicebindingoperator.redhat.io/status.dbCredentials-user":     "binding:env:object:secret",
+	}
+
+	return apiextensionv1.CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "CustomResourceDefinition",
+			APIVersion: "apiextensions.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        FullCRDName,
+			Annotations: annotations,
+		},
+		Spec: apiextensionv1.CustomResourceDefinitionSpec{
+			Group: CRDName,
+			Versions: []apiextensionv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha0", Served: false, Storage: false},
+				{Name: CRDVersion, Served: true, Storage: true},
+			},
+			Scope: apiextensionv1.NamespaceScoped,
+			Names: apiextensionv1.CustomResourceDefinitionNames{
+				Plural: CRDPlural,
+				Kind:   CRDKind,
+			},
+		},
+	}
+}
+
+func UnstructuredDatabaseCRDV1Mock() (*unstructured.Unstructured, error) {
+	crd := DatabaseCRDV1Mock()
+	return converter.ToUnstructured(&crd)
+}
+
 type PostgresDatabaseSpec struct {
 	Username string `json:"username"`
 }
@@ -345,12 +388,67 @@ func RouteCRMock(ns, name string) *ocv1.Route {
 	}
 }
 
+// BindableServiceMock returns a BindableService describing the database CRD, for the informed
+// version, or all versions when empty.
+func BindableServiceMock(name, version string) *v1alpha1.BindableService {
+	return &v1alpha1.BindableService{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "BindableService",
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1alpha1.BindableServiceSpec{
+			GroupVersionKind: metav1.GroupVersionKind{Group: CRDName, Version: version, Kind: CRDKind},
+			SpecDescriptors: []v1alpha1.BindableServiceDescriptor{
+				{Path: "image", XDescriptors: []string{"binding:env:attribute"}},
+			},
+			StatusDescriptors: []v1alpha1.BindableServiceDescriptor{
+				{Path: "dbCredentials", XDescriptors: []string{"binding:env:object:secret:user"}},
+			},
+		},
+	}
+}
+
+// ServiceMock returns a Service exposing a PostgreSQL database.
+func ServiceMock(ns, name string) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "172.30.0.10",
+			Ports: []corev1.ServicePort{
+				{Name: "postgresql", Port: 5432},
+				{Name: "metrics", Port: 9187},
+			},
+		},
+	}
+}
+
 // UnstructuredDatabaseCRMock returns a unstructured version of DatabaseCRMock.
 func UnstructuredDatabaseCRMock(ns, name string) (*unstructured.Unstructured, error) {
 	db := DatabaseCRMock(ns, name)
 	return converter.ToUnstructured(&db)
 }
 
+// UnstructuredProvisionedServiceMock returns a Database CR publishing the informed binding secret in
+// "status.binding.name", following the Provisioned Service duck type.
+func UnstructuredProvisionedServiceMock(ns, name, secretName string) (*unstructured.Unstructured, error) {
+	u, err := UnstructuredDatabaseCRMock(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	err = unstructured.SetNestedField(u.Object, secretName, "status", "binding", "name")
+	return u, err
+}
+
 // SecretMock returns a Secret based on PostgreSQL operator usage.
 func SecretMock(ns, name string) *corev1.Secret {
 	return &corev1.Secret{
@@ -478,6 +576,60 @@ func UnstructuredServiceBindingRequestMock(
 	return converter.ToUnstructuredAsGVK(&sbr, v1alpha1.SchemeGroupVersion.WithKind(OperatorKind))
 }
 
+// ServiceBindingMock returns a servicebinding.io ServiceBinding mock, equivalent to the one returned
+// by ServiceBindingRequestMock when binding as files without mount path prefix.
+func ServiceBindingMock(
+	ns string,
+	name string,
+	backingServiceResourceRef string,
+	applicationResourceRef string,
+	applicationGVK schema.GroupVersionKind,
+	matchLabels map[string]string,
+) *v1alpha3.ServiceBinding {
+	return &v1alpha3.ServiceBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha3.SchemeGroupVersion.String(),
+			Kind:       "ServiceBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+		},
+		Spec: v1alpha3.ServiceBindingSpec{
+			Workload: v1alpha3.ServiceBindingWorkloadReference{
+				APIVersion: applicationGVK.GroupVersion().String(),
+				Kind:       applicationGVK.Kind,
+				Name:       applicationResourceRef,
+				Selector:   &metav1.LabelSelector{MatchLabels: matchLabels},
+			},
+			Service: v1alpha3.ServiceBindingServiceReference{
+				APIVersion: schema.GroupVersion{Group: CRDName, Version: CRDVersion}.String(),
+				Kind:       CRDKind,
+				Name:       backingServiceResourceRef,
+			},
+			Mappings: []v1alpha3.ServiceBindingMapping{
+				{
+					Name:  "IMAGE_PATH",
+					Value: "spec.imagePath",
+				},
+			},
+		},
+	}
+}
+
+// UnstructuredServiceBindingMock returns a unstructured version of ServiceBindingMock.
+func UnstructuredServiceBindingMock(
+	ns string,
+	name string,
+	backingServiceResourceRef string,
+	applicationResourceRef string,
+	applicationGVK schema.GroupVersionKind,
+	matchLabels map[string]string,
+) (*unstructured.Unstructured, error) {
+	sb := ServiceBindingMock(ns, name, backingServiceResourceRef, applicationResourceRef, applicationGVK, matchLabels)
+	return converter.ToUnstructured(sb)
+}
+
 // DeploymentConfigListMock returns a list of DeploymentMock.
 func DeploymentConfigListMock(ns, name string, matchLabels map[string]string) ocav1.DeploymentConfigList {
 	return ocav1.DeploymentConfigList{
@@ -587,6 +739,93 @@ func DeploymentMock(ns, name string, matchLabels map[string]string) appsv1.Deplo
 	}
 }
 
+// UnstructuredCronJobMock converts the CronJobMock to unstructured.
+func UnstructuredCronJobMock(ns, name string, matchLabels map[string]string) (*unstructured.Unstructured, error) {
+	c := CronJobMock(ns, name, matchLabels)
+	return converter.ToUnstructured(&c)
+}
+
+// CronJobMock creates a mocked CronJob object of busybox.
+func CronJobMock(ns, name string, matchLabels map[string]string) batchv1beta1.CronJob {
+	return batchv1beta1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "CronJob",
+			APIVersion: "batch/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+			Labels:    matchLabels,
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule: "*/5 * * * *",
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:    "busybox",
+								Image:   "busybox:latest",
+								Command: []string{"date"},
+							}},
+							RestartPolicy: corev1.RestartPolicyOnFailure,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// UnstructuredPodMock converts the PodMock to unstructured.
+func UnstructuredPodMock(ns, name string, matchLabels map[string]string) (*unstructured.Unstructured, error) {
+	p := PodMock(ns, name, matchLabels)
+	return converter.ToUnstructured(&p)
+}
+
+// PodMock creates a mocked bare Pod object of busybox.
+func PodMock(ns, name string, matchLabels map[string]string) corev1.Pod {
+	return corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Pod",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+			Labels:    matchLabels,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:    "busybox",
+				Image:   "busybox:latest",
+				Command: []string{"sleep", "3600"},
+			}},
+		},
+	}
+}
+
+// UnstructuredNamespaceMock converts the NamespaceMock to unstructured.
+func UnstructuredNamespaceMock(name string, labels, annotations map[string]string) (*unstructured.Unstructured, error) {
+	ns := NamespaceMock(name, labels, annotations)
+	return converter.ToUnstructured(&ns)
+}
+
+// NamespaceMock creates a mocked Namespace object with the informed labels and annotations.
+func NamespaceMock(name string, labels, annotations map[string]string) corev1.Namespace {
+	return corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Namespace",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+	}
+}
+
 // KnativeServiceListMock returns a list of KnativeServiceMock.
 func KnativeServiceListMock(ns, name string, matchLabels map[string]string) knativev1.ServiceList {
 	return knativev1.ServiceList{